@@ -15,6 +15,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/go-rod/rod/lib/assets"
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/devices"
@@ -44,11 +45,14 @@ type Browser struct {
 
 	slowMotion time.Duration // see defaults.slow
 	trace      bool          // see defaults.Trace
+	stealth    bool
 	headless   bool
 	monitor    string
 
 	defaultDevice devices.Device
 
+	launcher *launcher.Launcher // the launcher of the browser process, nil if it's not launched by us
+
 	client      CDPClient
 	event       *goob.Observable // all the browser events from cdp client
 	targetsLock *sync.Mutex
@@ -68,13 +72,15 @@ func New() *Browser {
 		trace:         defaults.Trace,
 		monitor:       defaults.Monitor,
 		logger:        DefaultLogger,
-		defaultDevice: devices.LaptopWithMDPIScreen.Landescape(),
+		defaultDevice: devices.LaptopWithMDPIScreen.Landscape(),
 		targetsLock:   &sync.Mutex{},
 		states:        &sync.Map{},
 	}
 }
 
-// Incognito creates a new incognito browser
+// Incognito creates a new incognito browser.
+// The returned browser is a clone that creates all its pages inside a new browser context,
+// so cookies and storages are isolated from other contexts. Use Browser.Close to dispose the context.
 func (b *Browser) Incognito() (*Browser, error) {
 	res, err := proto.TargetCreateBrowserContext{}.Call(b)
 	if err != nil {
@@ -105,6 +111,15 @@ func (b *Browser) Trace(enable bool) *Browser {
 	return b
 }
 
+// Stealth enables/disables the js that hides the traces of the automation, such as navigator.webdriver.
+// It applies to the pages that rod starts to control after it's enabled, such as via Browser.Page, Browser.PageFromTarget,
+// or Page.WaitOpen, the js is evaluated on every new document of them. For a tab opened out of rod, such as a popup,
+// the document that is loaded before rod controls it won't be affected, reload it if you need the stealth.
+func (b *Browser) Stealth(enable bool) *Browser {
+	b.stealth = enable
+	return b
+}
+
 // Monitor address to listen if not empty. Shortcut for Browser.ServeMonitor
 func (b *Browser) Monitor(url string) *Browser {
 	b.monitor = url
@@ -123,6 +138,22 @@ func (b *Browser) Client(c CDPClient) *Browser {
 	return b
 }
 
+// Launcher sets the launcher of the browser process, Browser.Close will kill the process if it doesn't exit in time.
+// It's set automatically when Browser.Connect launches the browser.
+func (b *Browser) Launcher(l *launcher.Launcher) *Browser {
+	b.launcher = l
+	return b
+}
+
+// ProcessInfo returns the info of the browser process, such as the PID for monitoring.
+// It's nil if the browser isn't launched by the Browser.Launcher, such as a remote browser.
+func (b *Browser) ProcessInfo() *launcher.ProcessInfo {
+	if b.launcher == nil {
+		return nil
+	}
+	return b.launcher.ProcessInfo()
+}
+
 // DefaultDevice sets the default device for new page in the future. Default is devices.LaptopWithMDPIScreen .
 // Set it to devices.Clear to disable it.
 func (b *Browser) DefaultDevice(d devices.Device) *Browser {
@@ -141,7 +172,8 @@ func (b *Browser) Connect() error {
 	if b.client == nil {
 		u := defaults.URL
 		if u == "" {
-			u = launcher.New().Context(b.ctx).MustLaunch()
+			b.launcher = launcher.New().Context(b.ctx)
+			u = b.launcher.MustLaunch()
 		}
 		b.client = cdp.New(u)
 	}
@@ -165,12 +197,32 @@ func (b *Browser) Connect() error {
 	return b.setHeadless()
 }
 
-// Close the browser
+// closeTimeout is the max time to wait for the browser process to exit after the close
+const closeTimeout = 10 * time.Second
+
+// Close the browser. For an incognito browser, only its browser context will be disposed.
+// If the Browser.Launcher is set, Close will wait for the browser process to exit, the process will be killed if
+// it doesn't exit in 10 seconds or before the context of the browser is done.
 func (b *Browser) Close() error {
-	if b.BrowserContextID == "" {
-		return proto.BrowserClose{}.Call(b)
+	if b.BrowserContextID != "" {
+		return proto.TargetDisposeBrowserContext{BrowserContextID: b.BrowserContextID}.Call(b)
+	}
+
+	err := proto.BrowserClose{}.Call(b)
+
+	// the pid is zero if the launcher hasn't launched the process yet
+	if b.launcher != nil && b.launcher.PID() != 0 {
+		ctx, cancel := context.WithTimeout(b.ctx, closeTimeout)
+		defer cancel()
+
+		select {
+		case <-b.launcher.Exited():
+		case <-ctx.Done():
+			b.launcher.Kill()
+		}
 	}
-	return proto.TargetDisposeBrowserContext{BrowserContextID: b.BrowserContextID}.Call(b)
+
+	return err
 }
 
 // Page creates a new browser tab. If url is empty, the default target will be "about:blank".
@@ -249,6 +301,8 @@ func (b *Browser) PageFromSession(sessionID proto.TargetSessionID) *Page {
 }
 
 // PageFromTarget gets or creates a Page instance.
+// It's useful when you already know the target id of a tab, such as a tab discovered out of rod.
+// If the target is not a page, it will return ErrExpectPage.
 func (b *Browser) PageFromTarget(targetID proto.TargetTargetID) (*Page, error) {
 	b.targetsLock.Lock()
 	defer b.targetsLock.Unlock()
@@ -258,6 +312,14 @@ func (b *Browser) PageFromTarget(targetID proto.TargetTargetID) (*Page, error) {
 		return page, nil
 	}
 
+	info, err := b.pageInfo(targetID)
+	if err != nil {
+		return nil, err
+	}
+	if info.Type != proto.TargetTargetInfoTypePage {
+		return nil, &ErrExpectPage{info}
+	}
+
 	page = &Page{
 		ctx:       b.ctx,
 		sleeper:   b.sleeper,
@@ -272,7 +334,7 @@ func (b *Browser) PageFromTarget(targetID proto.TargetTargetID) (*Page, error) {
 	page.Keyboard = &Keyboard{page: page}
 	page.Touch = &Touch{page: page}
 
-	err := page.initSession()
+	err = page.initSession()
 	if err != nil {
 		return nil, err
 	}
@@ -284,13 +346,20 @@ func (b *Browser) PageFromTarget(targetID proto.TargetTargetID) (*Page, error) {
 		}
 	}
 
+	if b.stealth {
+		_, err = page.EvalOnNewDocument(assets.Stealth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	b.cachePage(page)
 
 	return page, nil
 }
 
 // EachEvent of the specified event types, if any callback returns true the wait function will resolve,
-// The type of each callback is (? means optional):
+// The type of each callback is (? means optional), the proto.TargetSessionID is the session that emits the event:
 //
 //     func(proto.Event, proto.TargetSessionID?) bool?
 //
@@ -304,13 +373,25 @@ func (b *Browser) EachEvent(callbacks ...interface{}) (wait func()) {
 
 // WaitEvent waits for the next event for one time. It will also load the data into the event object.
 func (b *Browser) WaitEvent(e proto.Event) (wait func()) {
-	return b.waitEvent("", e)
+	return b.waitEvent("", e, nil)
+}
+
+// WaitEventBy is similar to WaitEvent, but it only stops on the event that the match returns true.
+// Each event of the type will be loaded into e before the match is called, such as:
+//
+//     e := &proto.NetworkRequestWillBeSent{}
+//     wait := browser.WaitEventBy(e, func() bool { return strings.HasSuffix(e.Request.URL, "/api") })
+func (b *Browser) WaitEventBy(e proto.Event, match func() bool) (wait func()) {
+	return b.waitEvent("", e, match)
 }
 
 // waits for the next event for one time. It will also load the data into the event object.
-func (b *Browser) waitEvent(sessionID proto.TargetSessionID, e proto.Event) (wait func()) {
+// If match is not nil, it waits until the match returns true.
+func (b *Browser) waitEvent(sessionID proto.TargetSessionID, e proto.Event, match func() bool) (wait func()) {
 	valE := reflect.ValueOf(e)
-	valTrue := reflect.ValueOf(true)
+	if match == nil {
+		match = func() bool { return true }
+	}
 
 	if valE.Kind() != reflect.Ptr {
 		valE = reflect.New(valE.Type())
@@ -320,12 +401,12 @@ func (b *Browser) waitEvent(sessionID proto.TargetSessionID, e proto.Event) (wai
 	//
 	// func(ee proto.Event) bool {
 	//   *e = *ee
-	//   return true
+	//   return match()
 	// }
-	fnType := reflect.FuncOf([]reflect.Type{valE.Type()}, []reflect.Type{valTrue.Type()}, false)
+	fnType := reflect.FuncOf([]reflect.Type{valE.Type()}, []reflect.Type{reflect.TypeOf(true)}, false)
 	fnVal := reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
 		valE.Elem().Set(args[0].Elem())
-		return []reflect.Value{valTrue}
+		return []reflect.Value{reflect.ValueOf(match())}
 	})
 
 	return b.eachEvent(sessionID, fnVal.Interface())
@@ -379,7 +460,7 @@ func (b *Browser) eachEvent(sessionID proto.TargetSessionID, callbacks ...interf
 				msg.Load(e.Interface().(proto.Event))
 				args := []reflect.Value{e}
 				if cbVal.Type().NumIn() == 2 {
-					args = append(args, reflect.ValueOf(sessionID))
+					args = append(args, reflect.ValueOf(msg.SessionID))
 				}
 				res := cbVal.Call(args)
 				if len(res) > 0 {
@@ -424,12 +505,20 @@ func (b *Browser) initEvents() {
 	go func() {
 		defer b.event.Close()
 		for e := range b.client.Event() {
-			b.event.Publish(&Message{
+			msg := &Message{
 				SessionID: proto.TargetSessionID(e.SessionID),
 				Method:    e.Method,
 				lock:      &sync.Mutex{},
 				data:      e.Params,
-			})
+			}
+
+			// remove the cache before the subscribers receive the event, the target may be closed out of rod
+			destroyed := proto.TargetTargetDestroyed{}
+			if msg.Load(&destroyed) {
+				b.RemoveState(destroyed.TargetID)
+			}
+
+			b.event.Publish(msg)
 		}
 	}()
 }
@@ -447,13 +536,18 @@ func (b *Browser) IgnoreCertErrors(enable bool) error {
 	return proto.SecuritySetIgnoreCertificateErrors{Ignore: enable}.Call(b)
 }
 
+// Version info of the browser, such as the product name, the protocol version, and the user agent.
+func (b *Browser) Version() (*proto.BrowserGetVersionResult, error) {
+	return proto.BrowserGetVersion{}.Call(b)
+}
+
 // Headless mode or not
 func (b *Browser) Headless() bool {
 	return b.headless
 }
 
 func (b *Browser) setHeadless() error {
-	res, err := proto.BrowserGetVersion{}.Call(b)
+	res, err := b.Version()
 	if err != nil {
 		return err
 	}
@@ -467,7 +561,16 @@ func (b *Browser) setHeadless() error {
 	return nil
 }
 
-// GetCookies from the browser
+// GetCookies from the browser.
+// The cookies are JSON serializable, to persist them and restore them later:
+//
+//     data := utils.MustToJSONBytes(browser.MustGetCookies())
+//     // ...
+//     var cookies []*proto.NetworkCookie
+//     utils.E(json.Unmarshal(data, &cookies))
+//     browser.MustSetCookies(cookies)
+//
+// To save the localStorage and sessionStorage of a page too, use Page.SaveState.
 func (b *Browser) GetCookies() ([]*proto.NetworkCookie, error) {
 	res, err := proto.StorageGetCookies{BrowserContextID: b.BrowserContextID}.Call(b)
 	if err != nil {
@@ -476,10 +579,34 @@ func (b *Browser) GetCookies() ([]*proto.NetworkCookie, error) {
 	return res.Cookies, nil
 }
 
-// SetCookies to the browser
+// SetCookies to the browser. If the cookies is nil it will clear all the cookies of the browser context.
+// A cookie with SameSite None must be Secure, or an *ErrInsecureCookie will be returned.
+// To delete specific cookies, use proto.NetworkDeleteCookies, it matches the cookies by the name and
+// the optional url, domain, or path:
+//
+//     _ = proto.NetworkDeleteCookies{Name: "token", Domain: "example.com"}.Call(page)
 func (b *Browser) SetCookies(cookies []*proto.NetworkCookieParam) error {
+	if cookies == nil {
+		return proto.StorageClearCookies{BrowserContextID: b.BrowserContextID}.Call(b)
+	}
+
+	err := checkCookies(cookies)
+	if err != nil {
+		return err
+	}
+
 	return proto.StorageSetCookies{
 		Cookies:          cookies,
 		BrowserContextID: b.BrowserContextID,
 	}.Call(b)
 }
+
+// checkCookies rejects the cookies that the browser will silently drop
+func checkCookies(cookies []*proto.NetworkCookieParam) error {
+	for _, c := range cookies {
+		if c.SameSite == proto.NetworkCookieSameSiteNone && !c.Secure {
+			return &ErrInsecureCookie{c.Name}
+		}
+	}
+	return nil
+}
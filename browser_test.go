@@ -7,11 +7,14 @@ import (
 	"os"
 	"os/exec"
 	"runtime"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/devices"
+	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
 	"github.com/ysmood/gson"
@@ -68,6 +71,24 @@ func (t T) PageFromTarget() {
 		t.mc.stubErr(1, proto.EmulationSetDeviceMetricsOverride{})
 		t.browser.MustPageFromTargetID(res.TargetID)
 	})
+
+	res, err := proto.TargetCreateTarget{URL: "about:blank"}.Call(t.browser)
+	t.E(err)
+	defer func() {
+		t.browser.MustPageFromTargetID(res.TargetID).MustClose()
+	}()
+
+	t.mc.stub(1, proto.TargetGetTargetInfo{}, func(send StubSend) (gson.JSON, error) {
+		d, _ := send()
+		return *d.Set("targetInfo.type", "iframe"), nil
+	})
+	_, err = t.browser.PageFromTarget(res.TargetID)
+	t.Is(err, &rod.ErrExpectPage{})
+	t.Has(err.Error(), "expect target to be a page")
+
+	t.mc.stubErr(1, proto.TargetGetTargetInfo{})
+	_, err = t.browser.PageFromTarget(res.TargetID)
+	t.Err(err)
 }
 
 func (t T) BrowserPages() {
@@ -147,7 +168,7 @@ func (t T) BrowserWaitEvent() {
 	wait()
 
 	wait = t.browser.EachEvent(func(e *proto.PageFrameNavigated, id proto.TargetSessionID) bool {
-		return true
+		return id == t.page.SessionID
 	})
 	t.page.MustNavigate(t.blank())
 	wait()
@@ -178,6 +199,8 @@ func (t T) BrowserCall() {
 	t.E(err)
 
 	t.Regex("1.3", v.ProtocolVersion)
+
+	t.Has(t.browser.MustVersion().Product, "Chrome")
 }
 
 func (t T) BlockingNavigation() {
@@ -268,6 +291,28 @@ func (t T) BinarySize() {
 	t.Lte(float64(stat.Size())/1024/1024, 8.3) // mb
 }
 
+func (t T) BrowserConcurrentEval() {
+	pages := []*rod.Page{}
+	for i := 0; i < 5; i++ {
+		p := t.newPage(t.blank()).MustWaitLoad()
+		p.MustEval(`i => window.pageIndex = i`, i)
+		pages = append(pages, p)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			p := pages[i%len(pages)]
+			res := p.MustEval(`i => [window.pageIndex, i]`, i)
+			t.Eq(res.Get("0").Int(), i%len(pages))
+			t.Eq(res.Get("1").Int(), i)
+		}(i)
+	}
+	wg.Wait()
+}
+
 func (t T) BrowserCookies() {
 	b := t.browser.MustIncognito()
 	defer b.MustClose()
@@ -286,6 +331,123 @@ func (t T) BrowserCookies() {
 
 	t.mc.stubErr(1, proto.StorageGetCookies{})
 	t.Err(b.GetCookies())
+
+	b.MustSetCookies(nil)
+	t.Len(b.MustGetCookies(), 0)
+
+	err := b.SetCookies([]*proto.NetworkCookieParam{{
+		Name:     "a",
+		Domain:   "test.com",
+		SameSite: proto.NetworkCookieSameSiteNone,
+	}})
+	t.Is(err, &rod.ErrInsecureCookie{})
+	t.Eq(err.Error(), "the cookie with SameSite None must be Secure: a")
+}
+
+func (t T) BrowserCookiesRoundTrip() {
+	b := t.browser.MustIncognito()
+	defer b.MustClose()
+
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	// a cross-site cookie
+	b.MustSetCookies([]*proto.NetworkCookie{{
+		Name:     "a",
+		Value:    "1",
+		Domain:   "cross-site.com",
+		Path:     "/",
+		Secure:   true,
+		HTTPOnly: true,
+		SameSite: proto.NetworkCookieSameSiteNone,
+		Expires:  &proto.TimeSinceEpoch{Time: expires},
+	}, {
+		Name:     "b",
+		Value:    "2",
+		Domain:   "cross-site.com",
+		Path:     "/",
+		SameSite: proto.NetworkCookieSameSiteStrict,
+		Session:  true,
+	}})
+
+	other := t.browser.MustIncognito()
+	defer other.MustClose()
+	other.MustSetCookies(b.MustGetCookies())
+
+	cookies := other.MustGetCookies()
+	sort.Slice(cookies, func(i, j int) bool {
+		return cookies[i].Name < cookies[j].Name
+	})
+
+	t.Len(cookies, 2)
+	t.True(cookies[0].Secure)
+	t.True(cookies[0].HTTPOnly)
+	t.Eq(cookies[0].SameSite, proto.NetworkCookieSameSiteNone)
+	t.Eq(cookies[0].Expires.Unix(), expires.Unix())
+	t.False(cookies[0].Session)
+	t.Eq(cookies[1].SameSite, proto.NetworkCookieSameSiteStrict)
+	t.True(cookies[1].Session)
+}
+
+func (t T) BrowserStealth() {
+	b := t.browser.MustIncognito().Stealth(true)
+	defer b.MustClose()
+
+	p := b.MustPage(t.blank())
+	t.True(p.MustEval(`() => navigator.webdriver === undefined`).Bool())
+	t.Gt(p.MustEval(`() => navigator.plugins.length`).Int(), 0)
+	t.Gt(p.MustEval(`() => navigator.languages.length`).Int(), 0)
+	t.True(p.MustEval(`() => !!window.chrome.runtime`).Bool())
+
+	// it persists across navigations
+	p.MustNavigate(t.blank())
+	t.True(p.MustEval(`() => navigator.webdriver === undefined`).Bool())
+
+	// the page created out of Browser.Page
+	target, err := proto.TargetCreateTarget{URL: "about:blank", BrowserContextID: b.BrowserContextID}.Call(b)
+	t.E(err)
+	p = b.MustPageFromTargetID(target.TargetID).MustNavigate(t.blank())
+	t.True(p.MustEval(`() => navigator.webdriver === undefined`).Bool())
+
+	t.mc.stubErr(1, proto.PageAddScriptToEvaluateOnNewDocument{})
+	t.Err(b.Page(proto.TargetCreateTarget{}))
+}
+
+func (t T) BrowserCloseWithLauncher() {
+	l := launcher.New()
+	b := rod.New().ControlURL(l.MustLaunch()).Launcher(l).MustConnect()
+	t.Eq(b.ProcessInfo().PID, l.PID())
+	b.MustClose()
+	_, ok := <-l.Exited()
+	t.False(ok)
+
+	// kill the process if it can't be closed gracefully
+	l = launcher.New()
+	b = rod.New().ControlURL(l.MustLaunch()).Launcher(l).MustConnect()
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	t.Err(b.Context(ctx).Close())
+	_, ok = <-l.Exited()
+	t.False(ok)
+
+	// the launcher hasn't launched the process
+	b = rod.New().Client(&MockClient{
+		connect: func() error { return nil },
+		call: func(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+			return nil, nil
+		},
+		event: make(chan *cdp.Event),
+	}).Launcher(launcher.New())
+	t.Nil(b.ProcessInfo())
+	t.E(b.Close())
+	t.Nil(rod.New().ProcessInfo())
+}
+
+func (t T) BrowserSleep() {
+	t.browser.MustSleep(time.Millisecond)
+
+	b, cancel := t.browser.WithCancel()
+	cancel()
+	t.Eq(b.Sleep(time.Hour), context.Canceled)
 }
 
 func (t T) BrowserConnectErr() {
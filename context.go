@@ -31,7 +31,8 @@ func (b *Browser) Timeout(d time.Duration) *Browser {
 	return b.Context(context.WithValue(ctx, timeoutContextKey{}, &timeoutContextVal{b.ctx, cancel}))
 }
 
-// CancelTimeout cancels the current timeout context and returns a clone with the parent context
+// CancelTimeout cancels the current timeout context and returns a clone with the parent context.
+// It should be called after Timeout to release the resources of the timeout context.
 func (b *Browser) CancelTimeout() *Browser {
 	val := b.ctx.Value(timeoutContextKey{}).(*timeoutContextVal)
 	val.cancel()
@@ -44,6 +45,19 @@ func (b *Browser) WithCancel() (*Browser, func()) {
 	return b.Context(ctx), cancel
 }
 
+// Sleep for the duration d, it returns the error of the context if the context is done before d
+func (b *Browser) Sleep(d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-b.ctx.Done():
+		return b.ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
 // Sleeper returns a clone with for chained sub-operations
 func (b *Browser) Sleeper(sleeper func() utils.Sleeper) *Browser {
 	newObj := *b
@@ -69,7 +83,8 @@ func (p *Page) Timeout(d time.Duration) *Page {
 	return p.Context(context.WithValue(ctx, timeoutContextKey{}, &timeoutContextVal{p.ctx, cancel}))
 }
 
-// CancelTimeout cancels the current timeout context and returns a clone with the parent context
+// CancelTimeout cancels the current timeout context and returns a clone with the parent context.
+// It should be called after Timeout to release the resources of the timeout context.
 func (p *Page) CancelTimeout() *Page {
 	val := p.ctx.Value(timeoutContextKey{}).(*timeoutContextVal)
 	val.cancel()
@@ -107,7 +122,8 @@ func (el *Element) Timeout(d time.Duration) *Element {
 	return el.Context(context.WithValue(ctx, timeoutContextKey{}, &timeoutContextVal{el.ctx, cancel}))
 }
 
-// CancelTimeout cancels the current timeout context and returns a clone with the parent context
+// CancelTimeout cancels the current timeout context and returns a clone with the parent context.
+// It should be called after Timeout to release the resources of the timeout context.
 func (el *Element) CancelTimeout() *Element {
 	val := el.ctx.Value(timeoutContextKey{}).(*timeoutContextVal)
 	val.cancel()
@@ -3,6 +3,7 @@ package rod
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -37,15 +38,23 @@ func (el *Element) GetSessionID() proto.TargetSessionID {
 	return el.page.SessionID
 }
 
-// Focus sets focus on the specified element
+// Focus sets focus on the specified element.
+// If the element can't get the focus, such as a div without the tabindex, an *ErrNotFocusable will be returned.
 func (el *Element) Focus() error {
 	err := el.ScrollIntoView()
 	if err != nil {
 		return err
 	}
 
-	_, err = el.Evaluate(Eval(`this.focus()`).ByUser())
-	return err
+	res, err := el.Evaluate(Eval(`() => { this.focus(); return this.getRootNode().activeElement === this }`).ByUser())
+	if err != nil {
+		return err
+	}
+
+	if !res.Value.Bool() {
+		return &ErrNotFocusable{}
+	}
+	return nil
 }
 
 // ScrollIntoView scrolls the current element into the visible area of the browser
@@ -57,7 +66,11 @@ func (el *Element) ScrollIntoView() error {
 	return proto.DOMScrollIntoViewIfNeeded{ObjectID: el.id()}.Call(el)
 }
 
-// Hover the mouse over the center of the element.
+// Hover the mouse over the center of the element, it triggers the mouseover, mouseenter and the :hover css of it.
+// Such as to reveal a hover-only submenu:
+//
+//     page.MustElement(".menu").MustHover()
+//     page.MustElement(".menu .submenu").MustWaitVisible().MustClick()
 func (el *Element) Hover() error {
 	err := el.WaitVisible()
 	if err != nil {
@@ -83,6 +96,12 @@ func (el *Element) Hover() error {
 }
 
 // Click will press then release the button just like a human.
+// It waits until the element is visible, scrolls it into view, then moves the mouse to a point inside it.
+// The events are dispatched via the Input domain, so they are trusted events for the page.
+// If nothing of the element is rendered, it will return ErrInvisibleShape.
+// It won't wait for the animations, if the element is moving use Element.WaitStable before the click:
+//
+//     el.MustWaitStable().MustClick()
 func (el *Element) Click(button proto.InputMouseButton) error {
 	err := el.Hover()
 	if err != nil {
@@ -163,10 +182,26 @@ func (el *Element) Interactable() (pt *proto.Point, err error) {
 //     │    ┌───┘ = └────────┘ + ┌────┐
 //     └────┘                    └────┘
 //
+// Use Element.Box to get the position and size of the element.
 func (el *Element) Shape() (*proto.DOMGetContentQuadsResult, error) {
 	return proto.DOMGetContentQuads{ObjectID: el.id()}.Call(el)
 }
 
+// Box returns the smallest leveled rectangle that covers the Element.Shape, the position is relative to the viewport.
+// It covers the css-transformed element too. If nothing of the element is rendered, it will return ErrInvisibleShape.
+func (el *Element) Box() (*proto.DOMRect, error) {
+	shape, err := el.Shape()
+	if err != nil {
+		return nil, err
+	}
+
+	box := shape.Box()
+	if box == nil {
+		return nil, &ErrInvisibleShape{}
+	}
+	return box, nil
+}
+
 // Press a key
 func (el *Element) Press(key rune) error {
 	err := el.WaitVisible()
@@ -213,7 +248,7 @@ func (el *Element) SelectAllText() error {
 }
 
 // Input focuses on the element and input text to it.
-// To empty the input you can use something like el.SelectAllText().MustInput("")
+// To empty the input you can use Element.ClearInput
 func (el *Element) Input(text string) error {
 	err := el.WaitVisible()
 	if err != nil {
@@ -236,7 +271,23 @@ func (el *Element) Input(text string) error {
 	return err
 }
 
-// InputTime focuses on the element and input time to it.
+// ClearInput selects all the text of the element then deletes it with the Backspace key.
+// Because real key events are used, frameworks that ignore the direct value assignment,
+// such as React, will be notified too.
+func (el *Element) ClearInput() error {
+	err := el.SelectAllText()
+	if err != nil {
+		return err
+	}
+
+	defer el.tryTraceInput("clear input")()
+
+	return el.page.Keyboard.Press(input.Backspace)
+}
+
+// InputTime focuses on the element and input time to it, then dispatches the input and change events.
+// The value is formatted in the location of t by the type of the input, it supports the types of
+// date, datetime-local, month, time, and week. For other types, an *ErrEval will be returned.
 func (el *Element) InputTime(t time.Time) error {
 	err := el.WaitVisible()
 	if err != nil {
@@ -250,17 +301,28 @@ func (el *Element) InputTime(t time.Time) error {
 
 	defer el.tryTraceInput("input " + t.String())()
 
-	_, err = el.Evaluate(EvalHelper(js.InputTime, t.UnixNano()/1e6).ByUser())
+	year, week := t.ISOWeek()
+	values := map[string]string{
+		"date":           t.Format("2006-01-02"),
+		"datetime-local": t.Format("2006-01-02T15:04"),
+		"month":          t.Format("2006-01"),
+		"time":           t.Format("15:04"),
+		"week":           fmt.Sprintf("%04d-W%02d", year, week),
+	}
+
+	_, err = el.Evaluate(EvalHelper(js.InputTime, values).ByUser())
 	return err
 }
 
-// Blur is similar to the method Blur
+// Blur removes the focus from the element, it triggers the blur event of it
 func (el *Element) Blur() error {
 	_, err := el.Evaluate(Eval("this.blur()").ByUser())
 	return err
 }
 
-// Select the children option elements that match the selectors.
+// Select the children option elements that match the selectors, and dispatch the input and change events.
+// Use selected false to unselect them. For a multiple select you can pass several selectors.
+// Select by the visible text with SelectorTypeText, or by the value with SelectorTypeCSSSector like `[value="a"]`.
 func (el *Element) Select(selectors []string, selected bool, t SelectorType) error {
 	err := el.WaitVisible()
 	if err != nil {
@@ -283,7 +345,9 @@ func (el *Element) Matches(selector string) (bool, error) {
 	return res.Value.Bool(), nil
 }
 
-// Attribute is similar to the method Attribute
+// Attribute of the DOM element, such as href, src, or data-*.
+// It returns nil if the attribute doesn't exist, so you can tell it from an empty value.
+// Ref: https://developer.mozilla.org/en-US/docs/Web/API/Element/getAttribute
 func (el *Element) Attribute(name string) (*string, error) {
 	attr, err := el.Eval("(n) => this.getAttribute(n)", name)
 	if err != nil {
@@ -298,7 +362,8 @@ func (el *Element) Attribute(name string) (*string, error) {
 	return &s, nil
 }
 
-// Property is similar to the method Property
+// Property of the DOM object, such as the value of an input.
+// Ref: https://developer.mozilla.org/en-US/docs/Glossary/property/JavaScript
 func (el *Element) Property(name string) (gson.JSON, error) {
 	prop, err := el.Eval("(n) => this[n]", name)
 	if err != nil {
@@ -308,12 +373,17 @@ func (el *Element) Property(name string) (gson.JSON, error) {
 	return prop.Value, nil
 }
 
-// SetFiles of the current file input element
+// SetFiles of the current file input element, the paths will be converted to absolute paths.
+// It returns an error if any of the files doesn't exist.
 func (el *Element) SetFiles(paths []string) error {
 	absPaths := []string{}
 	for _, p := range paths {
 		absPath, err := filepath.Abs(p)
 		utils.E(err)
+		_, err = os.Stat(absPath)
+		if err != nil {
+			return err
+		}
 		absPaths = append(absPaths, absPath)
 	}
 
@@ -328,7 +398,10 @@ func (el *Element) SetFiles(paths []string) error {
 	return err
 }
 
-// Describe the current element
+// Describe the current element, such as the node name, node type, attributes, and child node count.
+// The depth is the max depth of the children to describe, -1 for the entire subtree.
+// If pierce is true, the iframes and shadow roots will be traversed too.
+// The result isn't cached, call it again after the node is changed.
 func (el *Element) Describe(depth int, pierce bool) (*proto.DOMNode, error) {
 	val, err := proto.DOMDescribeNode{ObjectID: el.id(), Depth: int(depth), Pierce: pierce}.Call(el)
 	if err != nil {
@@ -337,6 +410,15 @@ func (el *Element) Describe(depth int, pierce bool) (*proto.DOMNode, error) {
 	return val.Node, nil
 }
 
+// TagName of the element, it's lowercase for html elements, such as "a" or "button"
+func (el *Element) TagName() (string, error) {
+	node, err := el.Describe(0, false)
+	if err != nil {
+		return "", err
+	}
+	return node.LocalName, nil
+}
+
 // NodeID of the node
 func (el *Element) NodeID() (proto.DOMNodeID, error) {
 	el.page.enableNodeQuery()
@@ -347,13 +429,22 @@ func (el *Element) NodeID() (proto.DOMNodeID, error) {
 	return node.NodeID, nil
 }
 
-// ShadowRoot returns the shadow root of this element
+// ShadowRoot returns the shadow root of this element, the closed shadow root is also reachable.
+// Use the returned element to query the nodes inside the shadow root, such as:
+//
+//     page.MustElement("my-component").MustShadowRoot().MustElement("button")
+//
+// If the element has no shadow root, an *ErrNoShadowRoot will be returned.
 func (el *Element) ShadowRoot() (*Element, error) {
 	node, err := el.Describe(1, false)
 	if err != nil {
 		return nil, err
 	}
 
+	if len(node.ShadowRoots) == 0 {
+		return nil, &ErrNoShadowRoot{node}
+	}
+
 	// though now it's an array, w3c changed the spec of it to be a single.
 	id := node.ShadowRoots[0].BackendNodeID
 
@@ -365,7 +456,12 @@ func (el *Element) ShadowRoot() (*Element, error) {
 	return el.page.ElementFromObject(shadowNode.Object), nil
 }
 
-// Frame creates a page instance that represents the iframe
+// Frame creates a page instance that represents the iframe, the queries on it are scoped to the document of the iframe.
+// Use it recursively to reach the nested iframes, such as:
+//
+//     page.MustElement("iframe").MustFrame().MustElement("iframe").MustFrame().MustElement("button")
+//
+// To iterate all the iframes of a page, use Page.Elements("iframe") .
 func (el *Element) Frame() (*Page, error) {
 	node, err := el.Describe(1, false)
 	if err != nil {
@@ -389,7 +485,8 @@ func (el *Element) ContainsElement(target *Element) (bool, error) {
 	return res.Value.Bool(), nil
 }
 
-// Text that the element displays
+// Text that the element displays, it's the innerText of the element, so the whitespace is the same as what the browser renders.
+// For input and textarea it's the value or the placeholder, for select it's the text of the selected options.
 func (el *Element) Text() (string, error) {
 	str, err := el.Evaluate(EvalHelper(js.Text))
 	if err != nil {
@@ -398,7 +495,7 @@ func (el *Element) Text() (string, error) {
 	return str.Value.String(), nil
 }
 
-// HTML of the element
+// HTML of the element, it's the outerHTML of the element
 func (el *Element) HTML() (string, error) {
 	str, err := el.Eval(`this.outerHTML`)
 	if err != nil {
@@ -407,7 +504,11 @@ func (el *Element) HTML() (string, error) {
 	return str.Value.String(), nil
 }
 
-// Visible returns true if the element is visible on the page
+// Visible returns true if the element is visible on the page.
+// An element is visible if its computed display isn't "none", its visibility isn't "hidden", and any of the
+// top, bottom, width, or height of its bounding box isn't zero. So a zero-sized element that isn't at the top
+// of the page is still visible.
+// The opacity is ignored on purpose, because elements like styled checkboxes are often transparent but still interactable.
 func (el *Element) Visible() (bool, error) {
 	res, err := el.Evaluate(EvalHelper(js.Visible))
 	if err != nil {
@@ -425,7 +526,12 @@ func (el *Element) WaitLoad() error {
 // WaitStable waits until no shape or position change for d duration.
 // Be careful, d is not the max wait timeout, it's the least stable time.
 // If you want to set a timeout you can use the "Element.Timeout" function.
+// If d isn't positive, 100ms will be used.
 func (el *Element) WaitStable(d time.Duration) error {
+	if d <= 0 {
+		d = 100 * time.Millisecond
+	}
+
 	err := el.WaitVisible()
 	if err != nil {
 		return err
@@ -485,11 +591,34 @@ func (el *Element) WaitVisible() error {
 	return el.Wait(EvalHelper(js.Visible))
 }
 
-// WaitInvisible until the element invisible
+// WaitInvisible until the element invisible, an element removed from the document is also invisible.
+// It's useful to wait for spinners or modals to go away, such as:
+//
+//     page.MustElement(".spinner").MustWaitInvisible()
 func (el *Element) WaitInvisible() error {
 	return el.Wait(EvalHelper(js.Invisible))
 }
 
+// WaitEnabled until the disabled property of the element is false, such as a submit button that
+// is enabled after the form is filled
+func (el *Element) WaitEnabled() error {
+	return el.Wait(Eval(`() => !this.disabled`))
+}
+
+// Checked returns the checked property of the element, such as a checkbox or a radio
+func (el *Element) Checked() (bool, error) {
+	res, err := el.Eval(`() => this.checked`)
+	if err != nil {
+		return false, err
+	}
+	return res.Value.Bool(), nil
+}
+
+// WaitChecked until the checked property of the element equals the checked
+func (el *Element) WaitChecked(checked bool) error {
+	return el.Wait(Eval(`checked => this.checked === checked`, checked))
+}
+
 // CanvasToImage get image data of a canvas.
 // The default format is image/png.
 // The default quality is 0.92.
@@ -526,7 +655,7 @@ func (el *Element) BackgroundImage() ([]byte, error) {
 	return el.page.GetResource(u)
 }
 
-// Screenshot of the area of the element
+// Screenshot of the area of the element. If the element has zero area, ErrInvisibleShape will be returned.
 func (el *Element) Screenshot(format proto.PageCaptureScreenshotFormat, quality int) ([]byte, error) {
 	err := el.WaitVisible()
 	if err != nil {
@@ -538,8 +667,7 @@ func (el *Element) Screenshot(format proto.PageCaptureScreenshotFormat, quality
 		return nil, err
 	}
 
-	// so that it won't clip the css-transformed element
-	box, err := el.Evaluate(EvalHelper(js.Rect))
+	box, err := el.Box()
 	if err != nil {
 		return nil, err
 	}
@@ -547,14 +675,18 @@ func (el *Element) Screenshot(format proto.PageCaptureScreenshotFormat, quality
 	opts := &proto.PageCaptureScreenshot{
 		Format: format,
 		Clip: &proto.PageViewport{
-			X:      box.Value.Get("x").Num(),
-			Y:      box.Value.Get("y").Num(),
-			Width:  box.Value.Get("width").Num(),
-			Height: box.Value.Get("height").Num(),
+			X:      box.X,
+			Y:      box.Y,
+			Width:  box.Width,
+			Height: box.Height,
 			Scale:  1,
 		},
 	}
 
+	if opts.Clip.Width*opts.Clip.Height == 0 {
+		return nil, &ErrInvisibleShape{}
+	}
+
 	return el.page.Screenshot(false, opts)
 }
 
@@ -577,9 +709,10 @@ func (el *Element) Call(ctx context.Context, sessionID, methodName string, param
 	return el.page.Call(ctx, sessionID, methodName, params)
 }
 
-// Eval js on the page. For more info check the Element.Evaluate
+// Eval is similar to Page.Eval, but the "this" of the js will be the current element.
+// Such as `() => this.getAttribute('href')`. For more info check the Element.Evaluate
 func (el *Element) Eval(js string, params ...interface{}) (*proto.RuntimeRemoteObject, error) {
-	return el.Evaluate(Eval(js, params...))
+	return el.Evaluate(Eval(js, params...).ByPromise())
 }
 
 // Evaluate is just a shortcut of Page.Evaluate with This set to current element.
@@ -2,10 +2,12 @@ package rod_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"image/color"
 	"image/png"
+	"math"
 	"os"
 	"path/filepath"
 	"time"
@@ -168,6 +170,42 @@ func (t T) Iframes() {
 	t.True(frame02.MustHas("[a=ok]"))
 }
 
+func (t T) PageFrame() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/click-iframes.html"))
+	p.MustElement("iframe").MustFrame().MustElement("iframe").MustFrame().MustWaitLoad()
+
+	tree, err := proto.PageGetFrameTree{}.Call(p)
+	t.E(err)
+	id := tree.FrameTree.ChildFrames[0].ChildFrames[0].Frame.ID
+
+	frame02 := p.MustFrame(string(id))
+	t.True(frame02.IsIframe())
+	t.Eq(frame02.FrameID, id)
+	frame02.MustElement("button").MustClick()
+	t.True(frame02.MustHas("[a=ok]"))
+
+	p = t.newPage(t.blank())
+	p.MustSetDocumentContent(`<iframe name="f01" src="` + t.srcFile("fixtures/click.html") + `"></iframe>`)
+	p.MustElement("iframe").MustFrame().MustWaitLoad()
+	t.True(p.MustFrame("f01").MustHas("button"))
+
+	_, err = p.Frame("not-exists")
+	t.Is(err, &rod.ErrElementNotFound{})
+
+	t.Panic(func() {
+		t.mc.stubErr(1, proto.PageGetFrameTree{})
+		p.MustFrame("f01")
+	})
+	t.Panic(func() {
+		t.mc.stubErr(1, proto.DOMGetFrameOwner{})
+		p.MustFrame("f01")
+	})
+	t.Panic(func() {
+		t.mc.stubErr(1, proto.DOMResolveNode{})
+		p.MustFrame("f01")
+	})
+}
+
 func (t T) Contains() {
 	p := t.page.MustNavigate(t.srcFile("fixtures/click.html"))
 	a := p.MustElement("button")
@@ -193,6 +231,10 @@ func (t T) ShadowDOM() {
 		t.mc.stubErr(1, proto.DOMResolveNode{})
 		el.MustShadowRoot()
 	})
+
+	_, err := p.MustElement("body").ShadowRoot()
+	t.Is(err, &rod.ErrNoShadowRoot{})
+	t.Eq(err.Error(), "element has no shadow root: body")
 }
 
 func (t T) Press() {
@@ -222,6 +264,27 @@ func (t T) KeyDown() {
 	t.True(p.MustHas("body[event=key-down-j]"))
 }
 
+func (t T) KeyModifiers() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/keys.html"))
+	p.MustElement("body")
+	p.MustEval(`window.onkeydown = (e) => {
+		document.body.setAttribute('event', e.key + (e.ctrlKey ? '-ctrl' : '') + (e.shiftKey ? '-shift' : ''))
+	}`)
+
+	p.Keyboard.MustDown(input.Control)
+	p.Keyboard.MustDown(input.Shift)
+	p.Keyboard.MustPress('j')
+	t.True(p.MustHas("body[event=j-ctrl-shift]"))
+
+	p.Keyboard.MustUp(input.Shift)
+	p.Keyboard.MustPress('j')
+	t.True(p.MustHas("body[event=j-ctrl]"))
+
+	p.Keyboard.MustUp(input.Control)
+	p.Keyboard.MustPress('j')
+	t.True(p.MustHas("body[event=j]"))
+}
+
 func (t T) KeyUp() {
 	p := t.page.MustNavigate(t.srcFile("fixtures/keys.html"))
 	p.MustElement("body")
@@ -246,6 +309,24 @@ func (t T) Input() {
 	})
 }
 
+func (t T) ClearInput() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/input.html"))
+	el := p.MustElement("[type=text]")
+	el.MustInput("test")
+
+	t.Eq("", el.MustClearInput().MustText())
+	t.Eq("ok", el.MustInput("ok").MustText())
+
+	t.Panic(func() {
+		t.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustClearInput()
+	})
+	t.Panic(func() {
+		t.mc.stubErr(1, proto.InputDispatchKeyEvent{})
+		el.MustClearInput()
+	})
+}
+
 func (t T) InputTime() {
 	now := time.Now()
 
@@ -268,6 +349,20 @@ func (t T) InputTime() {
 		t.True(p.MustHas("[event=input-datetime-local-change]"))
 	}
 
+	{
+		p := t.newPage(t.blank())
+		p.MustSetDocumentContent(`<input type="month"><input type="time"><input type="week"><input type="text">`)
+		date := time.Date(2021, 1, 3, 4, 5, 0, 0, time.UTC)
+
+		t.Eq(p.MustElement("[type=month]").MustInputTime(date).MustText(), "2021-01")
+		t.Eq(p.MustElement("[type=time]").MustInputTime(date).MustText(), "04:05")
+		t.Eq(p.MustElement("[type=week]").MustInputTime(date).MustText(), "2020-W53")
+
+		err := p.MustElement("[type=text]").InputTime(date)
+		t.Is(err, &rod.ErrEval{})
+		t.Has(err.Error(), "not a date or time input: text")
+	}
+
 	t.Panic(func() {
 		t.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
 		el.MustInputTime(now)
@@ -324,6 +419,46 @@ func (t T) Blur() {
 	t.Eq("ok", *el.MustAttribute("a"))
 }
 
+func (t T) ElementEnabledAndChecked() {
+	p := t.newPage(t.blank())
+	p.MustSetDocumentContent(`<button disabled>ok</button><input type="checkbox">`)
+
+	btn := p.MustElement("button")
+	box := p.MustElement("input")
+	t.False(box.MustChecked())
+
+	p.MustEval(`() => setTimeout(() => {
+		document.querySelector('button').disabled = false
+		document.querySelector('input').checked = true
+	}, 100)`)
+
+	btn.MustWaitEnabled()
+	t.True(box.MustWaitChecked(true).MustChecked())
+
+	err := box.Timeout(100 * time.Millisecond).WaitChecked(false)
+	t.Is(err, context.DeadlineExceeded)
+
+	t.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	t.Err(box.Checked())
+}
+
+func (t T) Focus() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/input.html"))
+
+	el := p.MustElement("#blur").MustFocus()
+	t.True(p.MustEval(`() => document.activeElement.id === "blur"`).Bool())
+
+	el.MustBlur()
+	t.Eq("ok", *el.MustAttribute("a"))
+
+	err := p.MustElement("form").Focus()
+	t.Is(err, &rod.ErrNotFocusable{})
+	t.Eq(err.Error(), "the element can't get the focus")
+
+	t.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	t.Err(el.Focus())
+}
+
 func (t T) SelectQuery() {
 	p := t.page.MustNavigate(t.srcFile("fixtures/input.html"))
 	el := p.MustElement("select")
@@ -424,6 +559,8 @@ func (t T) SetFiles() {
 	list := el.MustEval("Array.from(this.files).map(f => f.name)").Arr()
 	t.Len(list, 2)
 	t.Eq("alert.html", list[1].String())
+
+	t.Is(el.SetFiles([]string{"not-exists"}), os.ErrNotExist)
 }
 
 func (t T) Enter() {
@@ -474,6 +611,8 @@ func (t T) WaitStable() {
 	})
 	t.Err(el.Context(ctx).WaitStable(time.Minute))
 
+	t.Nil(el.WaitStable(0))
+
 	t.Panic(func() {
 		t.mc.stubErr(1, proto.DOMGetContentQuads{})
 		el.MustWaitStable()
@@ -500,6 +639,7 @@ func (t T) Resource() {
 	p := t.page.MustNavigate(t.srcFile("fixtures/resource.html"))
 	el := p.MustElement("img")
 	t.Eq(len(el.MustResource()), 22661)
+	t.Eq(len(p.MustGetResource(el.MustProperty("currentSrc").String())), 22661)
 
 	t.mc.stub(1, proto.PageGetResourceContent{}, func(send StubSend) (gson.JSON, error) {
 		return gson.New(proto.PageGetResourceContentResult{
@@ -517,6 +657,10 @@ func (t T) Resource() {
 		t.mc.stubErr(1, proto.PageGetResourceContent{})
 		el.MustResource()
 	})
+	t.Panic(func() {
+		t.mc.stubErr(1, proto.PageGetResourceTree{})
+		el.MustResource()
+	})
 }
 
 func (t T) BackgroundImage() {
@@ -530,6 +674,30 @@ func (t T) BackgroundImage() {
 	}
 }
 
+func (t T) ElementBox() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/click.html"))
+	el := p.MustElement("h4")
+
+	box := el.MustBox()
+	t.Eq(box.Width, 200.0)
+	t.Eq(box.Height, 30.0)
+
+	// the box covers the rotated element
+	el.MustEval(`this.style.transform = 'rotate(90deg)'`)
+	box = el.MustBox()
+	t.Eq(math.Round(box.Width), 30.0)
+	t.Eq(math.Round(box.Height), 200.0)
+
+	el.MustEval(`this.style.display = 'none'`)
+	_, err := el.Box()
+	t.Is(err, &rod.ErrInvisibleShape{})
+
+	t.Panic(func() {
+		t.mc.stubErr(1, proto.DOMGetContentQuads{})
+		el.MustBox()
+	})
+}
+
 func (t T) ElementScreenshot() {
 	f := filepath.Join("tmp", "screenshots", t.Srand(16)+".png")
 	p := t.page.MustNavigate(t.srcFile("fixtures/click.html"))
@@ -551,9 +719,14 @@ func (t T) ElementScreenshot() {
 		el.MustScreenshot()
 	})
 	t.Panic(func() {
-		t.mc.stubErr(2, proto.RuntimeCallFunctionOn{})
+		t.mc.stubErr(1, proto.DOMGetContentQuads{})
 		el.MustScreenshot()
 	})
+
+	el.MustEval(`this.style.cssText = 'display: block; width: 0; padding: 0; border: 0; height: 10px'`)
+	_, err = el.Screenshot(proto.PageCaptureScreenshotFormatPng, 0)
+	var errShape *rod.ErrInvisibleShape
+	t.True(errors.As(err, &errShape))
 }
 
 func (t T) UseReleasedElement() {
@@ -588,6 +761,15 @@ func (t T) ElementMultipleTimes() {
 	t.Neq(btn01.Object, btn02.Object)
 }
 
+func (t T) ElementEval() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/click.html"))
+	el := p.MustElement("button")
+
+	t.Eq(el.MustEval(`(a, b) => this.tagName + a + b`, 1, 2).Str(), "BUTTON12")
+	t.Eq(el.MustEval(`() => Promise.resolve(this.tagName)`).Str(), "BUTTON")
+	t.Eq(el.MustEvaluate(rod.Eval(`this.tagName`)).Value.Str(), "BUTTON")
+}
+
 func (t T) FnErr() {
 	p := t.page.MustNavigate(t.srcFile("fixtures/click.html"))
 	el := p.MustElement("button")
@@ -621,7 +803,6 @@ func (t T) ElementEWithDepth() {
 func (t T) ElementOthers() {
 	p := t.page.MustNavigate(t.srcFile("fixtures/input.html"))
 	el := p.MustElement("form")
-	el.MustFocus()
 	el.MustScrollIntoView()
 	t.Eq("submit", el.MustElement("[type=submit]").MustText())
 	t.Eq("<input type=\"submit\" value=\"submit\">", el.MustElement("[type=submit]").MustHTML())
@@ -630,6 +811,16 @@ func (t T) ElementOthers() {
 	t.Len(el.MustElementsByJS(`[]`), 0)
 }
 
+func (t T) ElementTagName() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/input.html"))
+	t.Eq(p.MustElement("form").MustTagName(), "form")
+	t.Eq(p.MustElement("[type=submit]").MustTagName(), "input")
+
+	el := p.MustElement("form")
+	t.mc.stubErr(1, proto.DOMDescribeNode{})
+	t.Err(el.TagName())
+}
+
 func (t T) ElementFromPointErr() {
 	t.mc.stubErr(1, proto.DOMGetNodeForLocation{})
 	t.Err(t.page.ElementFromPoint(10, 10))
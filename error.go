@@ -58,6 +58,11 @@ func (e *ErrElementNotFound) Error() string {
 	return "cannot find element"
 }
 
+// Is interface
+func (e *ErrElementNotFound) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
 // ErrObjectNotFound error
 type ErrObjectNotFound struct {
 	*proto.RuntimeRemoteObject
@@ -101,6 +106,34 @@ func (e *ErrNavigation) Is(err error) bool {
 	return reflect.TypeOf(e) == reflect.TypeOf(err)
 }
 
+// ErrExpectPage error
+type ErrExpectPage struct {
+	*proto.TargetTargetInfo
+}
+
+func (e *ErrExpectPage) Error() string {
+	return fmt.Sprintf("expect target to be a page, but got: %s", utils.MustToJSON(e))
+}
+
+// Is interface
+func (e *ErrExpectPage) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrExpectWorker error
+type ErrExpectWorker struct {
+	*proto.TargetTargetInfo
+}
+
+func (e *ErrExpectWorker) Error() string {
+	return fmt.Sprintf("expect target to be a worker, but got: %s", utils.MustToJSON(e))
+}
+
+// Is interface
+func (e *ErrExpectWorker) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
 // ErrPageCloseCanceled error
 type ErrPageCloseCanceled struct {
 }
@@ -149,3 +182,161 @@ func (e *ErrCovered) Unwrap() error {
 func (e *ErrCovered) Is(err error) bool {
 	return reflect.TypeOf(e) == reflect.TypeOf(err)
 }
+
+// ErrInvalidGeolocation error
+type ErrInvalidGeolocation struct {
+	Latitude, Longitude, Accuracy float64
+}
+
+func (e *ErrInvalidGeolocation) Error() string {
+	return fmt.Sprintf("invalid geolocation: latitude %v, longitude %v, accuracy %v", e.Latitude, e.Longitude, e.Accuracy)
+}
+
+// Is interface
+func (e *ErrInvalidGeolocation) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrLoadingFailed error
+type ErrLoadingFailed struct {
+	*proto.NetworkLoadingFailed
+}
+
+func (e *ErrLoadingFailed) Error() string {
+	return fmt.Sprintf("failed to load the resource: %s", e.ErrorText)
+}
+
+// Is interface
+func (e *ErrLoadingFailed) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrDownloadCanceled error
+type ErrDownloadCanceled struct {
+	*proto.PageDownloadWillBegin
+}
+
+func (e *ErrDownloadCanceled) Error() string {
+	return fmt.Sprintf("download canceled: %s", e.URL)
+}
+
+// Is interface
+func (e *ErrDownloadCanceled) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrNotSecureContext error
+type ErrNotSecureContext struct {
+	Origin string
+}
+
+func (e *ErrNotSecureContext) Error() string {
+	return fmt.Sprintf("the api is only available in secure contexts, such as https or localhost: %s", e.Origin)
+}
+
+// Is interface
+func (e *ErrNotSecureContext) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrTargetClosed error
+type ErrTargetClosed struct {
+	TargetID proto.TargetTargetID
+	Err      error
+}
+
+func (e *ErrTargetClosed) Error() string {
+	return fmt.Sprintf("the target is closed: %s", e.TargetID)
+}
+
+// Unwrap interface
+func (e *ErrTargetClosed) Unwrap() error {
+	return e.Err
+}
+
+// Is interface
+func (e *ErrTargetClosed) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrReservedHeader error
+type ErrReservedHeader struct {
+	Name string
+}
+
+func (e *ErrReservedHeader) Error() string {
+	return fmt.Sprintf("the header is reserved by the browser: %s", e.Name)
+}
+
+// Is interface
+func (e *ErrReservedHeader) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrNoResponse error
+type ErrNoResponse struct {
+	RequestID proto.NetworkRequestID
+}
+
+func (e *ErrNoResponse) Error() string {
+	return fmt.Sprintf("no response received for the request: %s", e.RequestID)
+}
+
+// Is interface
+func (e *ErrNoResponse) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrResourceNotFound error
+type ErrResourceNotFound struct {
+	URL string
+}
+
+func (e *ErrResourceNotFound) Error() string {
+	return fmt.Sprintf("the resource isn't in the resource tree of the page: %s", e.URL)
+}
+
+// Is interface
+func (e *ErrResourceNotFound) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrInsecureCookie error
+type ErrInsecureCookie struct {
+	Name string
+}
+
+func (e *ErrInsecureCookie) Error() string {
+	return fmt.Sprintf("the cookie with SameSite None must be Secure: %s", e.Name)
+}
+
+// Is interface
+func (e *ErrInsecureCookie) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrNotFocusable error
+type ErrNotFocusable struct{}
+
+func (e *ErrNotFocusable) Error() string {
+	return "the element can't get the focus"
+}
+
+// Is interface
+func (e *ErrNotFocusable) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
+
+// ErrNoShadowRoot error
+type ErrNoShadowRoot struct {
+	*proto.DOMNode
+}
+
+func (e *ErrNoShadowRoot) Error() string {
+	return fmt.Sprintf("element has no shadow root: %s", e.LocalName)
+}
+
+// Is interface
+func (e *ErrNoShadowRoot) Is(err error) bool {
+	return reflect.TypeOf(e) == reflect.TypeOf(err)
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/devices"
 	"github.com/go-rod/rod/lib/input"
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
@@ -394,13 +395,29 @@ func Example_direct_cdp() {
 	}
 
 	{
-		// Interact with the cdp JSON API directly
-		_, _ = page.Call(context.TODO(), "", "Page.setAdBlockingEnabled", map[string]bool{
+		// Interact with the cdp JSON API directly, the session id routes the call to the page
+		_, _ = page.Call(context.TODO(), string(page.SessionID), "Page.setAdBlockingEnabled", map[string]bool{
 			"enabled": true,
 		})
 	}
 }
 
+// Shows how to throttle the network and the CPU to test a page on slow devices.
+func Example_throttling() {
+	page := rod.New().MustConnect().MustPage("")
+
+	// Emulate a slow 3G network, or customize the conditions via proto.NetworkEmulateNetworkConditions
+	page.MustThrottleNetwork(devices.Slow3G)
+
+	// Make the CPU 4x slower
+	page.MustThrottleCPU(4)
+
+	page.MustNavigate("https://example.com").MustWaitLoad()
+
+	// Test the offline behavior
+	page.MustOffline(true)
+}
+
 // Shows how to listen for events.
 func Example_handle_events() {
 	browser := rod.New().MustConnect()
@@ -97,8 +97,14 @@ func (r *HijackRouter) initEvents() *HijackRouter {
 				err := ctx.Response.payload.Call(r.client)
 				if err != nil {
 					ctx.OnError(err)
-					return
 				}
+				return
+			}
+
+			// no handler has taken the request, continue it so that the page won't hang
+			err := proto.FetchContinueRequest{RequestID: e.RequestID}.Call(r.client)
+			if err != nil {
+				ctx.OnError(err)
 			}
 		}()
 
@@ -200,7 +206,7 @@ type Hijack struct {
 	Response *HijackResponse
 	OnError  func(error)
 
-	// Skip to next handler
+	// Skip to next handler, if no handler takes the request it will be continued as is
 	Skip bool
 
 	continueRequest *proto.FetchContinueRequest
@@ -458,6 +464,13 @@ func (p *Page) GetDownloadFile(pattern string, resourceType proto.NetworkResourc
 
 // HandleAuth for the next basic HTTP authentication.
 // It will prevent the popup that requires user to input user name and password.
+// It also works for the authentication of the proxy server, check launcher.Launcher.Proxy .
+// It only handles the next request, the returned wait must be running when the page sends the request,
+// or the request will be paused forever:
+//
+//     go browser.MustHandleAuth("user", "password")()
+//     page.MustNavigate("https://example.com/protected")
+//
 // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Authentication
 func (b *Browser) HandleAuth(username, password string) func() error {
 	enable := b.DisableDomain("", &proto.FetchEnable{})
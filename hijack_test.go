@@ -114,6 +114,55 @@ func (t T) HijackContinue() {
 	wg.Wait()
 }
 
+func (t T) HijackSkipAll() {
+	s := t.Serve().Route("/", ".html", `<body>ok</body>`)
+
+	router := t.page.HijackRequests()
+	defer router.MustStop()
+
+	router.MustAdd(s.URL("/a"), func(ctx *rod.Hijack) {
+		ctx.Skip = true
+	})
+
+	go router.Run()
+
+	t.page.MustNavigate(s.URL("/a"))
+
+	t.Eq("ok", t.page.MustElement("body").MustText())
+}
+
+func (t T) HijackSkipAllErr() {
+	s := t.Serve().Route("/", ".html", `<body>ok</body>`)
+
+	router := t.page.HijackRequests()
+	defer router.MustStop()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	var err error
+
+	router.MustAdd(s.URL("/a"), func(ctx *rod.Hijack) {
+		ctx.OnError = func(e error) {
+			err = e
+			wg.Done()
+		}
+		ctx.Skip = true
+	})
+
+	go router.Run()
+
+	t.mc.stub(1, proto.FetchContinueRequest{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(nil), errors.New("err")
+	})
+
+	go func() {
+		_ = t.page.Context(t.Context()).Navigate(s.URL("/a"))
+	}()
+	wg.Wait()
+
+	t.Eq(err.Error(), "err")
+}
+
 func (t T) HijackOnErrorLog() {
 	s := t.Serve().Route("/", ".html", `<body>ok</body>`)
 
@@ -3,6 +3,7 @@ package rod
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/go-rod/rod/lib/input"
 	"github.com/go-rod/rod/lib/proto"
@@ -14,7 +15,7 @@ type Keyboard struct {
 
 	page *Page
 
-	// modifiers are currently beening pressed
+	// modifiers are currently being pressed
 	modifiers int
 }
 
@@ -25,18 +26,27 @@ func (k *Keyboard) getModifiers() int {
 	return k.modifiers
 }
 
-// Down holds the key down
+// Down holds the key down. If the key is a modifier key, such as input.Control, it will be applied to the
+// following key and mouse events until it's released by Keyboard.Up, for example to press Ctrl+A:
+//
+//     page.Keyboard.MustDown(input.Control)
+//     page.Keyboard.MustPress('a')
+//     page.Keyboard.MustUp(input.Control)
 func (k *Keyboard) Down(key rune) error {
 	k.Lock()
 	defer k.Unlock()
 
-	actions := input.Encode(key)
+	k.page.browser.trySlowmotion()
 
-	err := actions[0].Call(k.page)
+	action := input.Encode(key)[0]
+	modifiers := k.modifiers | input.Modifier(key)
+	action.Modifiers |= modifiers
+
+	err := action.Call(k.page)
 	if err != nil {
 		return err
 	}
-	k.modifiers = actions[0].Modifiers
+	k.modifiers = modifiers
 	return nil
 }
 
@@ -45,17 +55,23 @@ func (k *Keyboard) Up(key rune) error {
 	k.Lock()
 	defer k.Unlock()
 
+	k.page.browser.trySlowmotion()
+
 	actions := input.Encode(key)
+	action := actions[len(actions)-1]
+	modifiers := k.modifiers &^ input.Modifier(key)
+	action.Modifiers |= modifiers
 
-	err := actions[len(actions)-1].Call(k.page)
+	err := action.Call(k.page)
 	if err != nil {
 		return err
 	}
-	k.modifiers = 0
+	k.modifiers = modifiers
 	return nil
 }
 
-// Press a key. It's a combination of Keyboard.Down and Keyboard.Up
+// Press a key. It's a combination of Keyboard.Down and Keyboard.Up.
+// The modifier keys that are being held down will be applied to it.
 func (k *Keyboard) Press(key rune) error {
 	k.Lock()
 	defer k.Unlock()
@@ -63,14 +79,12 @@ func (k *Keyboard) Press(key rune) error {
 	if k.page.browser.trace {
 		defer k.page.Overlay(0, 0, 200, 0, "press "+input.Keys[key].Key)()
 	}
-	k.page.browser.trySlowmotion()
-
 	actions := input.Encode(key)
 
-	k.modifiers = actions[0].Modifiers
-	defer func() { k.modifiers = 0 }()
-
 	for _, action := range actions {
+		k.page.browser.trySlowmotion()
+
+		action.Modifiers |= k.modifiers
 		err := action.Call(k.page)
 		if err != nil {
 			return err
@@ -79,7 +93,34 @@ func (k *Keyboard) Press(key rune) error {
 	return nil
 }
 
-// InsertText is like pasting text into the page
+// Type the text key by key, so that the page will receive the key events of each char, it's slower than InsertText.
+// The delay is the time to wait between the chars, it waits on the context of the page, so canceling the context
+// stops the typing. The chars that are not on the keyboard, such as "中", will be inserted via InsertText.
+func (k *Keyboard) Type(text string, delay time.Duration) error {
+	for i, r := range text {
+		if i > 0 && delay > 0 {
+			err := k.page.browser.Context(k.page.ctx).Sleep(delay)
+			if err != nil {
+				return err
+			}
+		}
+
+		var err error
+		if _, has := input.Keys[r]; has || r == '\n' {
+			err = k.Press(r)
+		} else {
+			err = k.InsertText(string(r))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertText is like pasting text into the page. It only fires the input event, no key events.
+// Use it for emoji, multibyte chars, or the text composed by an IME, such as "你好".
+// Use Keyboard.Type or Keyboard.Press when the page listens to keydown or keyup events.
 func (k *Keyboard) InsertText(text string) error {
 	k.Lock()
 	defer k.Unlock()
@@ -108,7 +149,13 @@ type Mouse struct {
 	buttons []proto.InputMouseButton
 }
 
-// Move to the absolute position with specified steps
+// Move to the absolute position with specified steps, each step dispatches a mousemove event along the path.
+// Use it with Mouse.Down and Mouse.Up to drag things, such as sliders or sortable lists:
+//
+//     page.Mouse.MustMove(from.X, from.Y)
+//     page.Mouse.MustDown("left")
+//     utils.E(page.Mouse.Move(to.X, to.Y, 10))
+//     page.Mouse.MustUp("left")
 func (m *Mouse) Move(x, y float64, steps int) error {
 	m.Lock()
 	defer m.Unlock()
@@ -155,7 +202,8 @@ func (m *Mouse) Move(x, y float64, steps int) error {
 	return nil
 }
 
-// Scroll the relative offset with specified steps
+// Scroll the relative offset with specified steps, it dispatches mouseWheel events at the current position of the mouse,
+// such as to trigger the lazy loading of infinite-scroll pages. Use Element.ScrollIntoView to scroll to an element.
 func (m *Mouse) Scroll(offsetX, offsetY float64, steps int) error {
 	m.Lock()
 	defer m.Unlock()
@@ -163,7 +211,6 @@ func (m *Mouse) Scroll(offsetX, offsetY float64, steps int) error {
 	if m.page.browser.trace {
 		defer m.page.Overlay(0, 0, 200, 0, fmt.Sprintf("scroll (%.2f, %.2f)", offsetX, offsetY))()
 	}
-	m.page.browser.trySlowmotion()
 
 	if steps < 1 {
 		steps = 1
@@ -175,6 +222,8 @@ func (m *Mouse) Scroll(offsetX, offsetY float64, steps int) error {
 	stepY := offsetY / float64(steps)
 
 	for i := 0; i < steps; i++ {
+		m.page.browser.trySlowmotion()
+
 		err := proto.InputDispatchMouseEvent{
 			Type:      proto.InputDispatchMouseEventTypeMouseWheel,
 			X:         m.x,
@@ -198,6 +247,8 @@ func (m *Mouse) Down(button proto.InputMouseButton, clicks int) error {
 	m.Lock()
 	defer m.Unlock()
 
+	m.page.browser.trySlowmotion()
+
 	toButtons := append(m.buttons, button)
 
 	_, buttons := input.EncodeMouseButton(toButtons)
@@ -223,6 +274,8 @@ func (m *Mouse) Up(button proto.InputMouseButton, clicks int) error {
 	m.Lock()
 	defer m.Unlock()
 
+	m.page.browser.trySlowmotion()
+
 	toButtons := []proto.InputMouseButton{}
 	for _, btn := range m.buttons {
 		if btn == button {
@@ -238,6 +291,7 @@ func (m *Mouse) Up(button proto.InputMouseButton, clicks int) error {
 		Button:     button,
 		Buttons:    buttons,
 		ClickCount: clicks,
+		Modifiers:  m.page.Keyboard.getModifiers(),
 		X:          m.x,
 		Y:          m.y,
 	}.Call(m.page)
@@ -253,7 +307,6 @@ func (m *Mouse) Click(button proto.InputMouseButton) error {
 	if m.page.browser.trace {
 		defer m.page.Overlay(0, 0, 200, 0, "click "+string(button))()
 	}
-	m.page.browser.trySlowmotion()
 
 	err := m.Down(button, 1)
 	if err != nil {
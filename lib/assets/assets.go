@@ -188,3 +188,35 @@ const MonitorPage = `<html>
   </script>
 </html>
 `
+
+// Stealth js to hide the traces of the automation
+const Stealth = `(() => {
+  Object.defineProperty(Navigator.prototype, 'webdriver', {
+    get: () => undefined
+  })
+
+  if (navigator.plugins.length === 0) {
+    const plugins = [
+      { name: 'Chrome PDF Plugin', filename: 'internal-pdf-viewer' },
+      { name: 'Chrome PDF Viewer', filename: 'mhjfbmdgcfjbbpaeojofohoefgiehjai' },
+      { name: 'Native Client', filename: 'internal-nacl-plugin' }
+    ]
+    Object.defineProperty(Navigator.prototype, 'plugins', {
+      get: () => plugins
+    })
+  }
+
+  if (navigator.languages.length === 0) {
+    Object.defineProperty(Navigator.prototype, 'languages', {
+      get: () => ['en-US', 'en']
+    })
+  }
+
+  if (!window.chrome) {
+    window.chrome = {}
+  }
+  if (!window.chrome.runtime) {
+    window.chrome.runtime = {}
+  }
+})()
+`
@@ -21,10 +21,14 @@ const Monitor = {{.monitor}}
 
 // MonitorPage for rod
 const MonitorPage = {{.monitorPage}}
+
+// Stealth js to hide the traces of the automation
+const Stealth = {{.stealth}}
 `,
 		"mousePointer", get("../../fixtures/mouse-pointer.svg"),
 		"monitor", get("monitor.html"),
 		"monitorPage", get("monitor-page.html"),
+		"stealth", get("stealth.js"),
 	)
 
 	utils.E(utils.OutputFile(slash("lib/assets/assets.go"), build))
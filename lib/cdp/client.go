@@ -30,6 +30,8 @@ type Client struct {
 
 	count uint64
 
+	closeErr atomic.Value // the reason why the websocket is closed
+
 	logger utils.Logger
 }
 
@@ -108,7 +110,9 @@ func (cdp *Client) Websocket(ws WebSocketable) *Client {
 	return cdp
 }
 
-// Logger override for debugging
+// Logger override for debugging. It receives every *Request, *Response, and *Event that go through the client,
+// and the error that closes the websocket, you can type switch them to build your own structured log.
+// By default it's defaults.CDP, you can set the env "rod=cdp" to print them.
 func (cdp *Client) Logger(l utils.Logger) *Client {
 	cdp.logger = l
 	return cdp
@@ -143,7 +147,10 @@ func (cdp *Client) MustConnect(ctx context.Context) *Client {
 	return cdp
 }
 
-// Call a method and get its response, if ctx is nil context.Background() will be used
+// Call a method and get its response, if ctx is nil context.Background() will be used.
+// It's safe to call it concurrently, the requests are sent one by one, each response is routed to its caller by the id.
+// If the websocket connection is lost, an *ErrConnClosed will be returned, so it's safe to retry idempotent calls
+// on a new connection.
 func (cdp *Client) Call(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
 	req := &Request{
 		ID:        int(atomic.AddUint64(&cdp.count, 1)),
@@ -157,14 +164,15 @@ func (cdp *Client) Call(ctx context.Context, sessionID, method string, params in
 	data, err := json.Marshal(req)
 	utils.E(err)
 
-	callback := make(chan *Response)
+	// buffered, so the response won't block the client when the call is canceled
+	callback := make(chan *Response, 1)
 
 	cdp.callbacks.Store(req.ID, callback)
 	defer cdp.callbacks.Delete(req.ID)
 
 	select {
 	case <-cdp.ctx.Done():
-		return nil, cdp.ctx.Err()
+		return nil, cdp.ctxErr()
 
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -174,7 +182,7 @@ func (cdp *Client) Call(ctx context.Context, sessionID, method string, params in
 
 	select {
 	case <-cdp.ctx.Done():
-		return nil, cdp.ctx.Err()
+		return nil, cdp.ctxErr()
 
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -262,5 +270,13 @@ func (cdp *Client) readMsgFromBrowser() {
 
 func (cdp *Client) wsClose(err error) {
 	cdp.logger.Println(err)
+	cdp.closeErr.Store(&ErrConnClosed{err})
 	cdp.close()
 }
+
+func (cdp *Client) ctxErr() error {
+	if err, ok := cdp.closeErr.Load().(error); ok {
+		return err
+	}
+	return cdp.ctx.Err()
+}
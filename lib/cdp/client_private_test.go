@@ -2,8 +2,11 @@ package cdp
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"sync"
 	"testing"
 
 	"github.com/go-rod/rod/lib/utils"
@@ -51,14 +54,19 @@ func (t T) ReqErr() {
 	cdp := New("")
 	cdp.ctx = ctx
 	cdp.close = ctx.Cancel
+	errSend := errors.New("err")
 	cdp.ws = &MockWebSocket{
-		send: func([]byte) error { return errors.New("err") },
+		send: func([]byte) error { return errSend },
 	}
 
 	go cdp.consumeMsg()
 
 	_, err := cdp.Call(t.Context(), "", "", nil)
-	t.Err(err)
+	t.Eq(err.Error(), "cdp connection closed: err")
+	t.Is(err, errSend)
+
+	var errClosed *ErrConnClosed
+	t.True(errors.As(err, &errClosed))
 }
 
 func (t T) CancelOnReq() {
@@ -160,6 +168,51 @@ func (t T) CancelOnReadEvent() {
 	t.Err(err)
 }
 
+func (t T) ConcurrentCall() {
+	ctx := t.Context()
+	cdp := New("")
+	cdp.ctx = ctx
+	cdp.close = ctx.Cancel
+
+	const n = 300
+
+	queue := make(chan []byte, n)
+	cdp.ws = &MockWebSocket{
+		send: func(data []byte) error {
+			var req struct {
+				ID     int
+				Params json.RawMessage
+			}
+			utils.E(json.Unmarshal(data, &req))
+			queue <- utils.MustToJSONBytes(&Response{ID: req.ID, Result: req.Params})
+			return nil
+		},
+		read: func() ([]byte, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case data := <-queue:
+				return data, nil
+			}
+		},
+	}
+
+	go cdp.consumeMsg()
+	go cdp.readMsgFromBrowser()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res, err := cdp.Call(ctx, "", "", i)
+			t.E(err)
+			t.Eq(string(res), fmt.Sprint(i))
+		}(i)
+	}
+	wg.Wait()
+}
+
 func (t T) TestError() {
 	t.Is(&Error{Code: -123}, &Error{Code: -123})
 }
@@ -1,5 +1,7 @@
 package cdp
 
+import "fmt"
+
 // ErrCtxNotFound type
 var ErrCtxNotFound = &Error{
 	Code:    -32000,
@@ -17,3 +19,41 @@ var ErrObjNotFound = &Error{
 	Code:    -32000,
 	Message: "Could not find object with given id",
 }
+
+// ErrNodeDetached type, such as the node is removed from the document before the action on it
+var ErrNodeDetached = &Error{
+	Code:    -32000,
+	Message: "Node is detached from document",
+}
+
+// ErrSessionNotFound type, such as the target of the session is closed
+var ErrSessionNotFound = &Error{
+	Code:    -32001,
+	Message: "Session with given id not found.",
+}
+
+// ErrConnClosed type. The client won't reconnect automatically, because the sessions are bound to the connection,
+// after a reconnect all of them would be invalid. To recover, connect a new client to the same browser, then
+// attach to the existing targets again, such as via rod.Browser.PageFromTarget.
+type ErrConnClosed struct {
+	Err error
+}
+
+func (e *ErrConnClosed) Error() string {
+	return fmt.Sprintf("cdp connection closed: %v", e.Err)
+}
+
+// Unwrap interface
+func (e *ErrConnClosed) Unwrap() error {
+	return e.Err
+}
+
+// ErrNoRecord type
+type ErrNoRecord struct {
+	SessionID string
+	Method    string
+}
+
+func (e *ErrNoRecord) Error() string {
+	return fmt.Sprintf("no record for the call: %s %s", e.SessionID, e.Method)
+}
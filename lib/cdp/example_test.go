@@ -2,7 +2,9 @@ package cdp_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"os"
 
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/launcher"
@@ -41,3 +43,25 @@ func ExampleClient() {
 
 	// Output: 32
 }
+
+func ExampleClient_Logger() {
+	url := launcher.New().MustLaunch()
+
+	// dump the protocol traffic as json lines
+	enc := json.NewEncoder(os.Stdout)
+	client := cdp.New(url).Logger(utils.Log(func(msg ...interface{}) {
+		for _, m := range msg {
+			switch v := m.(type) {
+			case *cdp.Request, *cdp.Response, *cdp.Event:
+				_ = enc.Encode(v)
+			}
+		}
+	})).MustConnect(context.Background())
+
+	go func() {
+		for range client.Event() {
+		}
+	}()
+
+	_ = proto.BrowserClose{}.Call(client)
+}
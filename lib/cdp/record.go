@@ -0,0 +1,167 @@
+package cdp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// Record is a message that goes through the Client, only one of the Request, Response, and Event will be set.
+type Record struct {
+	Time     time.Time `json:"time"`
+	Request  *Request  `json:"request,omitempty"`
+	Response *Response `json:"response,omitempty"`
+	Event    *Event    `json:"event,omitempty"`
+}
+
+// Recorder writes the messages of a Client to a writer as json lines, use it as the logger of the Client:
+//
+//     client := cdp.New(u).Logger(cdp.NewRecorder(file))
+//
+// Use NewPlayer to replay the records.
+type Recorder struct {
+	lock sync.Mutex
+	enc  *json.Encoder
+	now  func() time.Time
+}
+
+// NewRecorder instance
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w), now: time.Now}
+}
+
+// Println interface, the values other than *Request, *Response, and *Event will be ignored
+func (r *Recorder) Println(msg ...interface{}) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, m := range msg {
+		rec := &Record{Time: r.now()}
+		switch v := m.(type) {
+		case *Request:
+			rec.Request = v
+		case *Response:
+			rec.Response = v
+		case *Event:
+			rec.Event = v
+		default:
+			continue
+		}
+		_ = r.enc.Encode(rec)
+	}
+}
+
+// Player replays the records of a Recorder without a browser, it implements the rod.CDPClient interface:
+//
+//     player, _ := cdp.NewPlayer(file)
+//     browser := rod.New().Client(player).MustConnect()
+//
+// A call is matched to the first recorded request that isn't replayed yet and has the same session id and method,
+// the params are ignored. The events are emitted in the recorded order, an event will be emitted only after
+// all the requests recorded before it are replayed. The time of the records is ignored.
+type Player struct {
+	lock sync.Mutex
+
+	requests  []*Request
+	responses map[int]*Response
+	records   []*Record
+	replayed  map[int]chan struct{}
+
+	chEvent chan *Event
+}
+
+// NewPlayer parses the records from the reader
+func NewPlayer(r io.Reader) (*Player, error) {
+	p := &Player{
+		responses: map[int]*Response{},
+		replayed:  map[int]chan struct{}{},
+		chEvent:   make(chan *Event),
+	}
+
+	dec := json.NewDecoder(r)
+	for {
+		var rec Record
+		err := dec.Decode(&rec)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case rec.Request != nil:
+			p.requests = append(p.requests, rec.Request)
+			p.replayed[rec.Request.ID] = make(chan struct{})
+		case rec.Response != nil:
+			p.responses[rec.Response.ID] = rec.Response
+		}
+		p.records = append(p.records, &rec)
+	}
+
+	return p, nil
+}
+
+// Connect interface, it starts to emit the events
+func (p *Player) Connect(ctx context.Context) error {
+	go p.emit(ctx)
+	return nil
+}
+
+func (p *Player) emit(ctx context.Context) {
+	defer close(p.chEvent)
+
+	for _, rec := range p.records {
+		switch {
+		case rec.Request != nil:
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.replayed[rec.Request.ID]:
+			}
+		case rec.Event != nil:
+			select {
+			case <-ctx.Done():
+				return
+			case p.chEvent <- rec.Event:
+			}
+		}
+	}
+}
+
+// Event interface
+func (p *Player) Event() <-chan *Event {
+	return p.chEvent
+}
+
+// Call interface, it returns the recorded response of the call.
+// If there's no record for the call, an *ErrNoRecord will be returned.
+func (p *Player) Call(_ context.Context, sessionID, method string, _ interface{}) ([]byte, error) {
+	p.lock.Lock()
+	var req *Request
+	for i, r := range p.requests {
+		if r.SessionID == sessionID && r.Method == method {
+			req = r
+			p.requests = append(p.requests[:i:i], p.requests[i+1:]...)
+			break
+		}
+	}
+	p.lock.Unlock()
+
+	if req == nil {
+		return nil, &ErrNoRecord{sessionID, method}
+	}
+
+	close(p.replayed[req.ID])
+
+	res, has := p.responses[req.ID]
+	if !has {
+		return nil, &ErrNoRecord{sessionID, method}
+	}
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return res.Result, nil
+}
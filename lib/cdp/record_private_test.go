@@ -0,0 +1,112 @@
+package cdp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func (t T) RecordAndReplay() {
+	ctx := t.Context()
+
+	buf := bytes.NewBuffer(nil)
+	rec := NewRecorder(buf)
+	rec.now = func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	queue := make(chan []byte, 10)
+	client := New("").Logger(rec)
+	client.ctx = ctx
+	client.close = ctx.Cancel
+	client.ws = &MockWebSocket{
+		send: func(data []byte) error {
+			var req Request
+			utils.E(json.Unmarshal(data, &req))
+			if req.Method == "A.call" {
+				queue <- []byte(`{"sessionId":"s","method":"A.event","params":{"n":1}}`)
+				queue <- utils.MustToJSONBytes(&Response{ID: req.ID, Result: []byte(`{"ok":true}`)})
+			} else {
+				queue <- utils.MustToJSONBytes(&Response{ID: req.ID, Error: &Error{Code: -1, Message: "err"}})
+			}
+			return nil
+		},
+		read: func() ([]byte, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case data := <-queue:
+				return data, nil
+			}
+		},
+	}
+
+	go client.consumeMsg()
+	go client.readMsgFromBrowser()
+	go func() {
+		for range client.Event() {
+		}
+	}()
+
+	res, err := client.Call(ctx, "s", "A.call", map[string]int{"a": 1})
+	t.E(err)
+	t.Eq(string(res), `{"ok":true}`)
+
+	_, err = client.Call(ctx, "", "A.fail", nil)
+	t.Is(err, &Error{Code: -1, Message: "err"})
+
+	rec.Println("the values other than messages are ignored")
+
+	golden, err := ioutil.ReadFile("fixtures/record.jsonl")
+	t.E(err)
+	t.Eq(buf.String(), string(golden))
+
+	player, err := NewPlayer(bytes.NewReader(golden))
+	t.E(err)
+	t.E(player.Connect(ctx))
+
+	res, err = player.Call(ctx, "s", "A.call", nil)
+	t.E(err)
+	t.Eq(string(res), `{"ok":true}`)
+
+	e := <-player.Event()
+	t.Eq(e.SessionID, "s")
+	t.Eq(e.Method, "A.event")
+	t.Eq(string(e.Params), `{"n":1}`)
+
+	_, err = player.Call(ctx, "", "A.fail", nil)
+	t.Is(err, &Error{Code: -1, Message: "err"})
+
+	_, ok := <-player.Event()
+	t.False(ok)
+
+	_, err = player.Call(ctx, "s", "A.call", nil)
+	t.Eq(err.Error(), "no record for the call: s A.call")
+}
+
+func (t T) PlayerErrs() {
+	_, err := NewPlayer(strings.NewReader("{"))
+	t.Err(err)
+
+	// the response is not recorded
+	player, err := NewPlayer(strings.NewReader(`{"request":{"id":1,"method":"A.call"}}`))
+	t.E(err)
+	_, err = player.Call(t.Context(), "", "A.call", nil)
+	var errNoRecord *ErrNoRecord
+	t.True(errors.As(err, &errNoRecord))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	player, err = NewPlayer(strings.NewReader(`{"request":{"id":1,"method":"A.call"}}`))
+	t.E(err)
+	player.emit(ctx)
+
+	player, err = NewPlayer(strings.NewReader(`{"event":{"method":"A.event"}}`))
+	t.E(err)
+	player.emit(ctx)
+}
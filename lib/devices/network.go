@@ -0,0 +1,29 @@
+package devices
+
+import "github.com/go-rod/rod/lib/proto"
+
+// The network presets are the same as the ones of the Chrome DevTools, use them with rod.Page.ThrottleNetwork .
+// The throughput is in bytes per second.
+var (
+	// Slow3G network
+	Slow3G = proto.NetworkEmulateNetworkConditions{
+		Latency:            2000,
+		DownloadThroughput: 500 * 1024 / 8 * 0.8,
+		UploadThroughput:   500 * 1024 / 8 * 0.8,
+		ConnectionType:     proto.NetworkConnectionTypeCellular3g,
+	}
+
+	// Fast3G network
+	Fast3G = proto.NetworkEmulateNetworkConditions{
+		Latency:            562.5,
+		DownloadThroughput: 1.6 * 1024 * 1024 / 8 * 0.9,
+		UploadThroughput:   750 * 1024 / 8 * 0.9,
+		ConnectionType:     proto.NetworkConnectionTypeCellular3g,
+	}
+
+	// NoThrottling is used to clear the network throttling
+	NoThrottling = proto.NetworkEmulateNetworkConditions{
+		DownloadThroughput: -1,
+		UploadThroughput:   -1,
+	}
+)
@@ -35,13 +35,20 @@ func New(json string) Device {
 	return Device{gson.NewFrom(json), false}
 }
 
-// Landescape clones the device and set it to landscape mode
-func (device Device) Landescape() Device {
+// Landscape clones the device and set it to landscape mode
+func (device Device) Landscape() Device {
 	d := device
 	d.landscape = true
 	return d
 }
 
+// Landescape is the misspelled Landscape.
+//
+// Deprecated: use Landscape instead.
+func (device Device) Landescape() Device {
+	return device.Landscape()
+}
+
 // Metrics config
 func (device Device) Metrics() *proto.EmulationSetDeviceMetricsOverride {
 	if device == Clear {
@@ -19,12 +19,13 @@ func TestErr(t *testing.T) {
 	as.True(v.Mobile)
 	as.True(touch.Enabled)
 
-	v = devices.LaptopWithMDPIScreen.Landescape().Metrics()
+	v = devices.LaptopWithMDPIScreen.Landscape().Metrics()
 	touch = devices.LaptopWithMDPIScreen.Touch()
 	as.Eq(1280, v.Width)
 	as.Eq(90, v.ScreenOrientation.Angle)
 	as.False(v.Mobile)
 	as.False(touch.Enabled)
+	as.Eq(v, devices.LaptopWithMDPIScreen.Landescape().Metrics())
 
 	u := devices.IPad.UserAgent()
 	as.Eq("Mozilla/5.0 (iPad; CPU OS 11_0 like Mac OS X) AppleWebKit/604.1.34 (KHTML, like Gecko) Version/11.0 Mobile/15A5341f Safari/604.1", u.UserAgent)
@@ -95,3 +95,19 @@ func Encode(r rune) []*proto.InputDispatchKeyEvent {
 
 	return []*proto.InputDispatchKeyEvent{&keyDown, &keyUp}
 }
+
+// Modifier returns the bit of the modifier key for proto.InputDispatchKeyEvent.Modifiers,
+// such as Alt=1, Control=2, Meta=4, Shift=8. It returns 0 if the key is not a modifier key.
+func Modifier(r rune) int {
+	switch r {
+	case Alt:
+		return 1
+	case Control:
+		return 2
+	case Meta:
+		return 4
+	case Shift:
+		return 8
+	}
+	return 0
+}
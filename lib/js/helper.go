@@ -65,13 +65,6 @@ var UpdateMouseTracer = &Function{
 	Dependencies: []*Function{},
 }
 
-// Rect ...
-var Rect = &Function{
-	Name:         "rect",
-	Definition:   `function(){const e=functions.tag(this).getBoundingClientRect();return{x:e.x,y:e.y,width:e.width,height:e.height}}`,
-	Dependencies: []*Function{Tag},
-}
-
 // Overlay ...
 var Overlay = &Function{
 	Name:         "overlay",
@@ -117,7 +110,7 @@ var InputEvent = &Function{
 // InputTime ...
 var InputTime = &Function{
 	Name:         "inputTime",
-	Definition:   `function(e){const t=new Date(e),n=e=>e.toString().padStart(2,"0"),i=t.getFullYear(),s=n(t.getMonth()+1),o=n(t.getDate()),r=n(t.getHours()),l=n(t.getMinutes());switch(this.type){case"date":this.value=` + "`" + `${i}-${s}-${o}` + "`" + `;break;case"datetime-local":this.value=` + "`" + `${i}-${s}-${o}T${r}:${l}` + "`" + `;break;case"month":this.value=s;break;case"time":this.value=` + "`" + `${r}:${l}` + "`" + `}functions.inputEvent.call(this)}`,
+	Definition:   `function(e){if(!(this.type in e))throw new Error(` + "`" + `not a date or time input: ${this.type}` + "`" + `);this.value=e[this.type],functions.inputEvent.call(this)}`,
 	Dependencies: []*Function{InputEvent},
 }
 
@@ -97,11 +97,6 @@ const functions = {
     return true
   },
 
-  rect() {
-    const b = functions.tag(this).getBoundingClientRect()
-    return { x: b.x, y: b.y, width: b.width, height: b.height }
-  },
-
   async overlay(id, left, top, width, height, msg) {
     await functions.waitLoad()
 
@@ -209,32 +204,13 @@ const functions = {
     this.dispatchEvent(new Event('change', { bubbles: true }))
   },
 
-  inputTime(stamp) {
-    const time = new Date(stamp)
-
-    const pad = (n) => n.toString().padStart(2, '0')
-
-    const y = time.getFullYear()
-    const mon = pad(time.getMonth() + 1)
-    const d = pad(time.getDate())
-    const h = pad(time.getHours())
-    const min = pad(time.getMinutes())
-
-    switch (this.type) {
-      case 'date':
-        this.value = `${y}-${mon}-${d}`
-        break
-      case 'datetime-local':
-        this.value = `${y}-${mon}-${d}T${h}:${min}`
-        break
-      case 'month':
-        this.value = mon
-        break
-      case 'time':
-        this.value = `${h}:${min}`
-        break
+  inputTime(values) {
+    if (!(this.type in values)) {
+      throw new Error(`not a date or time input: ${this.type}`)
     }
 
+    this.value = values[this.type]
+
     functions.inputEvent.call(this)
   },
 
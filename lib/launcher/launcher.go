@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/go-rod/rod/lib/defaults"
@@ -33,6 +34,7 @@ type Launcher struct {
 	parser    *URLParser
 	Flags     map[string][]string `json:"flags"`
 	pid       int
+	execPath  string
 	exit      chan struct{}
 	remote    bool // remote mode or not
 	leakless  bool
@@ -180,11 +182,19 @@ func (l *Launcher) Delete(name string) *Launcher {
 }
 
 // Bin set browser executable file path. If it's empty, launcher will automatically search or download the bin.
+// It can be a path or a name in the PATH, such as "chromium", Launch returns an error if it's not executable.
 func (l *Launcher) Bin(path string) *Launcher {
 	l.bin = path
 	return l
 }
 
+// Revision of the browser to auto download. Default is DefaultRevision.
+// It only takes effect when the bin is not set and the browser is not found on the machine.
+func (l *Launcher) Revision(rev int) *Launcher {
+	l.browser.Revision = rev
+	return l
+}
+
 // Headless switch. Whether to run browser in headless mode. A mode without visible UI.
 func (l *Launcher) Headless(enable bool) *Launcher {
 	if enable {
@@ -210,6 +220,8 @@ func (l *Launcher) Devtools(autoOpenForTabs bool) *Launcher {
 
 // UserDataDir is where the browser will look for all of its state, such as cookie and cache.
 // When set to empty, browser will use current OS home dir.
+// Set it to a fixed dir to reuse the logins, extensions, and cache between runs. Multiple browser processes
+// can't use the same dir at the same time. Don't call Launcher.Cleanup if you want to keep the dir.
 // Related doc: https://chromium.googlesource.com/chromium/src/+/master/docs/user_data_dir.md
 func (l *Launcher) UserDataDir(dir string) *Launcher {
 	if dir == "" {
@@ -243,7 +255,8 @@ func (l *Launcher) RemoteDebuggingPort(port int) *Launcher {
 	return l.Set("remote-debugging-port", fmt.Sprintf("%d", port))
 }
 
-// Proxy switch. When disabled leakless will be disabled.
+// Proxy for the browser, such as "127.0.0.1:8080" or "socks5://127.0.0.1:1080".
+// If the proxy requires authentication, use rod.Browser.HandleAuth to respond the credentials.
 func (l *Launcher) Proxy(host string) *Launcher {
 	return l.Set("proxy-server", host)
 }
@@ -260,6 +273,24 @@ func (l *Launcher) Env(env ...string) *Launcher {
 	return l.Set(flagEnv, env...)
 }
 
+// Extensions to load, each path is the dir of an unpacked extension.
+// It will disable the headless mode, because the headless browser can't load extensions.
+// Launcher.Launch will return an error if any of the paths doesn't exist.
+func (l *Launcher) Extensions(paths ...string) *Launcher {
+	list := []string{}
+	for _, p := range paths {
+		abs, err := filepath.Abs(p)
+		if err == nil {
+			p = abs
+		}
+		list = append(list, p)
+	}
+
+	return l.Headless(false).
+		Set("load-extension", list...).
+		Set("disable-extensions-except", list...)
+}
+
 // StartURL to launch
 func (l *Launcher) StartURL(u string) *Launcher {
 	return l.Set("", u)
@@ -295,6 +326,8 @@ func (l *Launcher) FormatArgs() []string {
 
 // Logger to handle stdout and stderr from browser.
 // For example, pipe all browser output to stdout: launcher.New().Logger(os.Stdout)
+// If the launch fails and the output has a fatal line, the error only contains the first fatal line,
+// use it to check the full output.
 func (l *Launcher) Logger(w io.Writer) *Launcher {
 	l.logger = w
 	return l
@@ -313,6 +346,13 @@ func (l *Launcher) MustLaunch() string {
 func (l *Launcher) Launch() (string, error) {
 	defer l.ctxCancel()
 
+	for _, p := range l.Flags["load-extension"] {
+		_, err := os.Stat(p)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	bin, err := l.getBin()
 	if err != nil {
 		return "", err
@@ -340,6 +380,8 @@ func (l *Launcher) Launch() (string, error) {
 		return "", err
 	}
 
+	l.execPath = bin
+
 	if ll == nil {
 		l.pid = cmd.Process.Pid
 	} else {
@@ -378,7 +420,12 @@ func (l *Launcher) getBin() (string, error) {
 		l.browser.Context = l.ctx
 		return l.browser.Get()
 	}
-	return l.bin, nil
+
+	bin, err := exec.LookPath(l.bin)
+	if err != nil {
+		return "", fmt.Errorf("[launcher] the bin isn't executable: %w", err)
+	}
+	return bin, nil
 }
 
 func (l *Launcher) getURL() (u string, err error) {
@@ -389,16 +436,59 @@ func (l *Launcher) getURL() (u string, err error) {
 	case <-l.exit:
 		l.parser.Lock()
 		defer l.parser.Unlock()
-		err = errors.New("[launcher] Failed to get the debug url " + l.parser.Buffer)
+		err = launchErr(l.parser.Buffer)
 	}
 	return
 }
 
+// the error lines are ignored, because the browser prints harmless ones on almost every start, such as the dbus ones
+var regFatal = regexp.MustCompile(`(?m)^.*:FATAL:.*$`)
+
+// launchErr reports the first fatal line of the browser output, or the whole output if there's no fatal line,
+// such as when a shared library is missing. It gives hints for the common failures.
+func launchErr(out string) error {
+	msg := out
+	if line := regFatal.FindString(out); line != "" {
+		msg = line
+	}
+	msg = "[launcher] Failed to get the debug url " + strings.TrimSpace(msg)
+
+	if strings.Contains(out, "No usable sandbox") {
+		msg += `, try to disable the sandbox via Launcher.Set("no-sandbox")`
+	}
+
+	return errors.New(msg)
+}
+
 // PID returns the browser process pid
 func (l *Launcher) PID() int {
 	return l.pid
 }
 
+// ProcessInfo of a browser process launched by the Launcher
+type ProcessInfo struct {
+	// ExecPath of the browser executable
+	ExecPath string
+
+	PID int
+
+	// Kill the browser process
+	Kill func()
+}
+
+// ProcessInfo returns the info of the launched browser process, it's nil if the Launcher hasn't launched the process,
+// such as when Launch reuses an existing browser on the remote-debugging-port.
+func (l *Launcher) ProcessInfo() *ProcessInfo {
+	if l.pid == 0 {
+		return nil
+	}
+	return &ProcessInfo{
+		ExecPath: l.execPath,
+		PID:      l.pid,
+		Kill:     l.Kill,
+	}
+}
+
 // Kill the browser process
 func (l *Launcher) Kill() {
 	// TODO: If kill too fast, the browser's children processes may not be ready.
@@ -411,6 +501,11 @@ func (l *Launcher) Kill() {
 	}
 }
 
+// Exited returns a channel that will be closed when the browser process exits
+func (l *Launcher) Exited() <-chan struct{} {
+	return l.exit
+}
+
 // Cleanup wait until the Browser exits and remove UserDataDir
 func (l *Launcher) Cleanup() {
 	<-l.exit
@@ -81,9 +81,15 @@ func (t T) Launch() {
 	l := launcher.New()
 	defer l.Kill()
 
+	t.Nil(l.ProcessInfo())
+
 	u := l.MustLaunch()
 	t.Regex(`\Aws://.+\z`, u)
 
+	info := l.ProcessInfo()
+	t.Eq(info.PID, l.PID())
+	t.Neq(info.ExecPath, "")
+
 	parsed, _ := url.Parse(u)
 
 	{ // test GetWebSocketDebuggerURL
@@ -100,6 +106,10 @@ func (t T) Launch() {
 		_, err = launcher.NewRemote("ws://not-exists")
 		t.Err(err)
 	}
+
+	l.Kill()
+	_, ok := <-l.Exited()
+	t.False(ok)
 }
 
 func (t T) LaunchUserMode() {
@@ -5,6 +5,8 @@ import (
 	"io/ioutil"
 	"net/url"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -41,6 +43,12 @@ func (t T) Unzip() {
 	t.Err(unzip(ioutil.Discard, "", ""))
 }
 
+func (t T) Revision() {
+	l := New()
+	t.Eq(DefaultRevision, l.browser.Revision)
+	t.Eq(123, l.Revision(123).browser.Revision)
+}
+
 func (t T) LaunchOptions() {
 	defaults.Show = true
 	defaults.Devtools = true
@@ -64,6 +72,23 @@ func (t T) LaunchOptions() {
 	t.True(has)
 }
 
+func (t T) Extensions() {
+	dir := t.Srand(16)
+	l := New().Extensions(dir)
+
+	_, has := l.Get("headless")
+	t.False(has)
+
+	abs, _ := filepath.Abs(dir)
+	list, _ := l.GetFlags("load-extension")
+	t.Eq(list, []string{abs})
+	list, _ = l.GetFlags("disable-extensions-except")
+	t.Eq(list, []string{abs})
+
+	_, err := l.Launch()
+	t.Is(err, os.ErrNotExist)
+}
+
 func (t T) GetURLErr() {
 	l := New()
 
@@ -80,6 +105,19 @@ func (t T) GetURLErr() {
 	t.Eq("[launcher] Failed to get the debug url err", err.Error())
 }
 
+func (t T) LaunchErrMsg() {
+	t.Eq(launchErr("a\n[0101/000000.000:ERROR:gpu_init.cc(426)] x\n[0101/000000.000:FATAL:main.cc(1)] y\n").Error(),
+		"[launcher] Failed to get the debug url [0101/000000.000:FATAL:main.cc(1)] y")
+
+	t.Eq(launchErr("[0101/000000.000:ERROR:bus.cc(393)] x\nchrome: error while loading shared libraries: libnss3.so\n").Error(),
+		"[launcher] Failed to get the debug url [0101/000000.000:ERROR:bus.cc(393)] x\n"+
+			"chrome: error while loading shared libraries: libnss3.so")
+
+	t.Eq(launchErr("[0101/000000.000:FATAL:zygote_host_impl_linux.cc(117)] No usable sandbox!\n").Error(),
+		"[launcher] Failed to get the debug url [0101/000000.000:FATAL:zygote_host_impl_linux.cc(117)] No usable sandbox!"+
+			`, try to disable the sandbox via Launcher.Set("no-sandbox")`)
+}
+
 func (t T) RemoteLaunch() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -105,6 +143,18 @@ func (t T) RemoteLaunch() {
 	t.Err(os.Stat(dir))
 }
 
+func (t T) BinNotExecutable() {
+	p := filepath.Join(t.Srand(16), "chrome")
+	utils.E(utils.OutputFile(p, ""))
+	defer func() { _ = os.RemoveAll(filepath.Dir(p)) }()
+
+	_, err := New().Bin(p).Launch()
+	t.Has(err.Error(), "[launcher] the bin isn't executable")
+
+	_, err = New().Bin(t.Srand(16)).Launch()
+	t.Is(err, exec.ErrNotFound)
+}
+
 func (t T) LaunchErrs() {
 	l := New().Bin("echo")
 	_, err := l.Launch()
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"reflect"
+	"time"
 
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
@@ -101,11 +102,17 @@ func (t T) Rect() {
 
 	res := &proto.DOMGetContentQuadsResult{}
 	t.Nil(res.OnePointInside())
+	t.Nil(res.Box())
 
 	res = &proto.DOMGetContentQuadsResult{Quads: []proto.DOMQuad{rect}}
 	pt := res.OnePointInside()
 	t.Eq(348.5, pt.X)
 	t.Eq(399.25, pt.Y)
+
+	res = &proto.DOMGetContentQuadsResult{Quads: []proto.DOMQuad{rect, {
+		300, 400, 310, 400, 310, 430, 300, 430,
+	}}}
+	t.Eq(&proto.DOMRect{X: 300, Y: 382, Width: 61, Height: 48}, res.Box())
 }
 
 func (t T) InputTouchPointMoveTo() {
@@ -124,6 +131,12 @@ func (t T) CookiesToParams() {
 
 	t.Eq(list[0].Name, "name")
 	t.Eq(list[0].Value, "val")
+
+	list = proto.CookiesToParams([]*proto.NetworkCookie{{
+		Expires: &proto.TimeSinceEpoch{Time: time.Unix(-1, 0)},
+		Session: true,
+	}})
+	t.Nil(list[0].Expires)
 }
 
 func (t T) GeneratorOptimize() {
@@ -4,6 +4,7 @@ package proto
 
 import (
 	"encoding/json"
+	"math"
 	"strconv"
 	"time"
 
@@ -131,16 +132,41 @@ func (res *DOMGetContentQuadsResult) OnePointInside() *Point {
 	return &center
 }
 
-// MoveTo X and Y to x and y
+// Box returns the smallest leveled rectangle that can cover the whole shape, it returns nil if the shape is empty.
+func (res *DOMGetContentQuadsResult) Box() *DOMRect {
+	if len(res.Quads) == 0 {
+		return nil
+	}
+
+	left, top := math.Inf(1), math.Inf(1)
+	right, bottom := math.Inf(-1), math.Inf(-1)
+
+	for _, q := range res.Quads {
+		q.Each(func(pt Point, _ int) {
+			left = math.Min(left, pt.X)
+			top = math.Min(top, pt.Y)
+			right = math.Max(right, pt.X)
+			bottom = math.Max(bottom, pt.Y)
+		})
+	}
+
+	return &DOMRect{X: left, Y: top, Width: right - left, Height: bottom - top}
+}
+
 func (p *InputTouchPoint) MoveTo(x, y float64) {
 	p.X = x
 	p.Y = y
 }
 
-// CookiesToParams converts Cookies list to NetworkCookieParam list
+// CookiesToParams converts Cookies list to NetworkCookieParam list.
+// The Expires of a session cookie will be dropped, or the cookie will expire immediately.
 func CookiesToParams(cookies []*NetworkCookie) []*NetworkCookieParam {
 	list := []*NetworkCookieParam{}
 	for _, c := range cookies {
+		expires := c.Expires
+		if c.Session {
+			expires = nil
+		}
 		list = append(list, &NetworkCookieParam{
 			Name:     c.Name,
 			Value:    c.Value,
@@ -149,7 +175,7 @@ func CookiesToParams(cookies []*NetworkCookie) []*NetworkCookieParam {
 			Secure:   c.Secure,
 			HTTPOnly: c.HTTPOnly,
 			SameSite: c.SameSite,
-			Expires:  c.Expires,
+			Expires:  expires,
 			Priority: c.Priority,
 		})
 	}
@@ -171,6 +171,26 @@ func CountSleeper(max int) Sleeper {
 	}
 }
 
+// EachSleepers returns a sleeper that calls each of the sleepers one by one,
+// it returns the first error it gets. Such as to retry at most 5 times in a backoff manner:
+//
+//     EachSleepers(CountSleeper(5), BackoffSleeper(time.Second, 10*time.Second, nil))
+//
+// When it's used with Retry and the count is reached, Retry returns the "max sleep count" error of the
+// CountSleeper, not the last error of the fn, because the error of fn is ignored when it doesn't stop.
+// To get the last error of fn, keep it in a variable outside of the fn.
+func EachSleepers(list ...Sleeper) Sleeper {
+	return func(ctx context.Context) error {
+		for _, s := range list {
+			err := s(ctx)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 // DefaultBackoff algorithm: A(n) = A(n-1) * random[1.9, 2.1)
 func DefaultBackoff(interval time.Duration) time.Duration {
 	scale := 2 + (mr.Float64()-0.5)*0.2
@@ -271,7 +291,8 @@ func (de *IdleCounter) Wait(ctx context.Context) {
 	}
 }
 
-// Retry fn and sleeper until fn returns true or s returns error
+// Retry fn and sleeper until fn returns true or s returns error.
+// Use EachSleepers to limit the attempts of a backoff sleeper.
 func Retry(ctx context.Context, s Sleeper, fn func() (stop bool, err error)) error {
 	for {
 		stop, err := fn()
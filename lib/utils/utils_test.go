@@ -179,6 +179,21 @@ func (t T) CountSleeperCancel() {
 	t.Eq(s(t.Timeout(0)), context.DeadlineExceeded)
 }
 
+func (t T) EachSleepers() {
+	ctx := t.Context()
+	count := 0
+	counter := func(context.Context) error {
+		count++
+		return nil
+	}
+	s := utils.EachSleepers(utils.CountSleeper(2), counter)
+
+	t.E(s(ctx))
+	t.E(s(ctx))
+	t.Err(s(ctx))
+	t.Eq(2, count)
+}
+
 func (t T) MustToJSON() {
 	t.Eq(utils.Dump("a", 10), `"a" 10`)
 	t.Eq(`{"a":1}`, utils.MustToJSON(map[string]int{"a": 1}))
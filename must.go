@@ -31,6 +31,12 @@ func (b *Browser) MustClose() {
 	_ = b.Close()
 }
 
+// MustSleep is similar to Sleep
+func (b *Browser) MustSleep(d time.Duration) *Browser {
+	utils.E(b.Sleep(d))
+	return b
+}
+
 // MustIncognito is similar to Incognito
 func (b *Browser) MustIncognito() *Browser {
 	b, err := b.Incognito()
@@ -52,7 +58,7 @@ func (b *Browser) MustPages() Pages {
 	return list
 }
 
-// MustPageFromTargetID is similar to PageFromTargetID
+// MustPageFromTargetID is similar to PageFromTarget
 func (b *Browser) MustPageFromTargetID(targetID proto.TargetTargetID) *Page {
 	p, err := b.PageFromTarget(targetID)
 	utils.E(err)
@@ -71,6 +77,13 @@ func (b *Browser) MustIgnoreCertErrors(enable bool) *Browser {
 	return b
 }
 
+// MustVersion is similar to Version
+func (b *Browser) MustVersion() *proto.BrowserGetVersionResult {
+	v, err := b.Version()
+	utils.E(err)
+	return v
+}
+
 // MustGetCookies is similar GetCookies
 func (b *Browser) MustGetCookies() []*proto.NetworkCookie {
 	nc, err := b.GetCookies()
@@ -80,10 +93,24 @@ func (b *Browser) MustGetCookies() []*proto.NetworkCookie {
 
 // MustSetCookies is similar SetCookies
 func (b *Browser) MustSetCookies(cookies []*proto.NetworkCookie) *Browser {
+	if cookies == nil {
+		utils.E(b.SetCookies(nil))
+		return b
+	}
 	utils.E(b.SetCookies(proto.CookiesToParams(cookies)))
 	return b
 }
 
+// MustWaitWorker is similar to WaitWorker
+func (b *Browser) MustWaitWorker() func() *Worker {
+	wait := b.WaitWorker()
+	return func() *Worker {
+		w, err := wait()
+		utils.E(err)
+		return w
+	}
+}
+
 // MustFind is similar to Find
 func (ps Pages) MustFind(selector string) *Page {
 	p, err := ps.Find(selector)
@@ -112,12 +139,55 @@ func (p *Page) MustCookies(urls ...string) []*proto.NetworkCookie {
 	return cookies
 }
 
+// MustSaveState is similar to SaveState
+func (p *Page) MustSaveState() *SessionState {
+	state, err := p.SaveState()
+	utils.E(err)
+	return state
+}
+
+// MustRestoreState is similar to RestoreState
+func (p *Page) MustRestoreState(state *SessionState) *Page {
+	utils.E(p.RestoreState(state))
+	return p
+}
+
 // MustSetCookies is similar to SetCookies
 func (p *Page) MustSetCookies(cookies ...*proto.NetworkCookieParam) *Page {
 	utils.E(p.SetCookies(cookies))
 	return p
 }
 
+// MustSetBypassCSP is similar to SetBypassCSP
+func (p *Page) MustSetBypassCSP(enable bool) *Page {
+	utils.E(p.SetBypassCSP(enable))
+	return p
+}
+
+// MustThrottleNetwork is similar to ThrottleNetwork
+func (p *Page) MustThrottleNetwork(conditions proto.NetworkEmulateNetworkConditions) *Page {
+	utils.E(p.ThrottleNetwork(conditions))
+	return p
+}
+
+// MustOffline is similar to Offline
+func (p *Page) MustOffline(enable bool) *Page {
+	utils.E(p.Offline(enable))
+	return p
+}
+
+// MustThrottleCPU is similar to ThrottleCPU
+func (p *Page) MustThrottleCPU(rate float64) *Page {
+	utils.E(p.ThrottleCPU(rate))
+	return p
+}
+
+// MustSetBlockedURLs is similar to SetBlockedURLs
+func (p *Page) MustSetBlockedURLs(patterns ...string) *Page {
+	utils.E(p.SetBlockedURLs(patterns))
+	return p
+}
+
 // MustSetExtraHeaders is similar to SetExtraHeaders
 func (p *Page) MustSetExtraHeaders(dict ...string) (cleanup func()) {
 	cleanup, err := p.SetExtraHeaders(dict)
@@ -131,18 +201,68 @@ func (p *Page) MustSetUserAgent(req *proto.NetworkSetUserAgentOverride) *Page {
 	return p
 }
 
+// MustSetGeolocation is similar to SetGeolocation
+func (p *Page) MustSetGeolocation(latitude, longitude, accuracy float64) *Page {
+	utils.E(p.SetGeolocation(latitude, longitude, accuracy))
+	return p
+}
+
+// MustSetTimezone is similar to SetTimezone
+func (p *Page) MustSetTimezone(id string) *Page {
+	utils.E(p.SetTimezone(id))
+	return p
+}
+
+// MustSetLocale is similar to SetLocale
+func (p *Page) MustSetLocale(locale string) *Page {
+	utils.E(p.SetLocale(locale))
+	return p
+}
+
+// MustReadClipboard is similar to ReadClipboard
+func (p *Page) MustReadClipboard() string {
+	text, err := p.ReadClipboard()
+	utils.E(err)
+	return text
+}
+
+// MustWriteClipboard is similar to WriteClipboard
+func (p *Page) MustWriteClipboard(text string) *Page {
+	utils.E(p.WriteClipboard(text))
+	return p
+}
+
 // MustNavigate is similar to Navigate
 func (p *Page) MustNavigate(url string) *Page {
 	utils.E(p.Navigate(url))
 	return p
 }
 
+// MustNavigateWithOptions is similar to NavigateWithOptions
+func (p *Page) MustNavigateWithOptions(opts *proto.PageNavigate) *Page {
+	utils.E(p.NavigateWithOptions(opts))
+	return p
+}
+
 // MustReload is similar to Reload
 func (p *Page) MustReload() *Page {
 	utils.E(p.Reload())
 	return p
 }
 
+// MustSetDocumentContent is similar to SetDocumentContent
+func (p *Page) MustSetDocumentContent(html string) *Page {
+	utils.E(p.SetDocumentContent(html))
+	return p
+}
+
+// MustHTML is similar to HTML
+func (p *Page) MustHTML() string {
+	html, err := p.HTML()
+	utils.E(err)
+	return html
+}
+
 // MustNavigateBack is similar to NavigateBack
 func (p *Page) MustNavigateBack() *Page {
 	utils.E(p.NavigateBack())
@@ -223,6 +343,18 @@ func (p *Page) MustEmulate(device devices.Device) *Page {
 	return p
 }
 
+// MustEmulateMedia is similar to EmulateMedia
+func (p *Page) MustEmulateMedia(media string, features map[string]string) *Page {
+	utils.E(p.EmulateMedia(media, features))
+	return p
+}
+
+// MustDarkMode is similar to DarkMode
+func (p *Page) MustDarkMode() *Page {
+	utils.E(p.DarkMode())
+	return p
+}
+
 // MustStopLoading is similar to StopLoading
 func (p *Page) MustStopLoading() *Page {
 	utils.E(p.StopLoading())
@@ -260,6 +392,40 @@ func (p *Page) MustScreenshotFullPage(toFile ...string) []byte {
 	return bin
 }
 
+// MustGetResourceTree is similar to GetResourceTree
+func (p *Page) MustGetResourceTree() *proto.PageFrameResourceTree {
+	tree, err := p.GetResourceTree()
+	utils.E(err)
+	return tree
+}
+
+// MustStartTracing is similar to StartTracing
+func (p *Page) MustStartTracing(categories ...string) *Page {
+	utils.E(p.StartTracing(categories))
+	return p
+}
+
+// MustStopTracing is similar to StopTracing
+func (p *Page) MustStopTracing() []byte {
+	trace, err := p.StopTracing()
+	utils.E(err)
+	return trace
+}
+
+// MustMetrics is similar to Metrics
+func (p *Page) MustMetrics() map[string]float64 {
+	metrics, err := p.Metrics()
+	utils.E(err)
+	return metrics
+}
+
+// MustGetResource is similar to GetResource
+func (p *Page) MustGetResource(url string) []byte {
+	bin, err := p.GetResource(url)
+	utils.E(err)
+	return bin
+}
+
 // MustPDF is similar to PDF.
 // If the toFile is "", it will save output to "tmp/pdf" folder, time as the file name.
 func (p *Page) MustPDF(toFile ...string) []byte {
@@ -272,6 +438,15 @@ func (p *Page) MustPDF(toFile ...string) []byte {
 	return bin
 }
 
+// MustCaptureSnapshot is similar to CaptureSnapshot.
+// If the toFile is "", it will save output to "tmp/mhtml" folder, time as the file name.
+func (p *Page) MustCaptureSnapshot(toFile ...string) string {
+	mhtml, err := p.CaptureSnapshot()
+	utils.E(err)
+	utils.E(saveFile(saveFileTypeMHTML, []byte(mhtml), toFile))
+	return mhtml
+}
+
 // MustGetDownloadFile is similar to GetDownloadFile
 func (p *Page) MustGetDownloadFile(pattern string) func() []byte {
 	wait := p.GetDownloadFile(pattern, "", http.DefaultClient)
@@ -292,6 +467,16 @@ func (p *Page) MustWaitOpen() (wait func() (newPage *Page)) {
 	}
 }
 
+// MustWaitFileChooser is similar to WaitFileChooser
+func (p *Page) MustWaitFileChooser() func() *Element {
+	wait := p.WaitFileChooser()
+	return func() *Element {
+		el, err := wait()
+		utils.E(err)
+		return el
+	}
+}
+
 // MustWaitPauseOpen is similar to WaitPauseOpen
 func (p *Page) MustWaitPauseOpen() (wait func() (p *Page, resume func())) {
 	w, err := p.WaitPauseOpen()
@@ -311,6 +496,36 @@ func (p *Page) MustWaitNavigation() func() {
 	return p.WaitNavigation(proto.PageLifecycleEventNameNetworkAlmostIdle)
 }
 
+// MustWaitRequest is similar to WaitRequest
+func (p *Page) MustWaitRequest(pattern string) func() *proto.NetworkRequest {
+	wait := p.WaitRequest(pattern)
+	return func() *proto.NetworkRequest {
+		req, err := wait()
+		utils.E(err)
+		return req
+	}
+}
+
+// MustWaitDownload is similar to WaitDownload
+func (p *Page) MustWaitDownload(dir string) func() string {
+	wait := p.WaitDownload(dir)
+	return func() string {
+		path, err := wait()
+		utils.E(err)
+		return path
+	}
+}
+
+// MustWaitResponse is similar to WaitResponse
+func (p *Page) MustWaitResponse(pattern string) func() (*proto.NetworkResponse, []byte) {
+	wait := p.WaitResponse(pattern)
+	return func() (*proto.NetworkResponse, []byte) {
+		res, body, err := wait()
+		utils.E(err)
+		return res, body
+	}
+}
+
 // MustWaitRequestIdle is similar to WaitRequestIdle
 func (p *Page) MustWaitRequestIdle(excludes ...string) (wait func()) {
 	return p.WaitRequestIdle(300*time.Millisecond, nil, excludes)
@@ -322,6 +537,12 @@ func (p *Page) MustWaitIdle() *Page {
 	return p
 }
 
+// MustWaitSettled is similar to WaitSettled
+func (p *Page) MustWaitSettled() *Page {
+	utils.E(p.WaitSettled(300*time.Millisecond, time.Minute))
+	return p
+}
+
 // MustWaitLoad is similar to WaitLoad
 func (p *Page) MustWaitLoad() *Page {
 	utils.E(p.WaitLoad())
@@ -341,9 +562,10 @@ func (p *Page) MustAddStyleTag(url string) *Page {
 }
 
 // MustEvalOnNewDocument is similar to EvalOnNewDocument
-func (p *Page) MustEvalOnNewDocument(js string) {
-	_, err := p.EvalOnNewDocument(js)
+func (p *Page) MustEvalOnNewDocument(js string) (remove func()) {
+	r, err := p.EvalOnNewDocument(js)
 	utils.E(err)
+	return func() { utils.E(r()) }
 }
 
 // MustExpose is similar to Expose
@@ -382,13 +604,9 @@ func (p *Page) MustObjectToJSON(obj *proto.RuntimeRemoteObject) gson.JSON {
 
 // MustObjectsToJSON is similar to ObjectsToJSON
 func (p *Page) MustObjectsToJSON(list []*proto.RuntimeRemoteObject) gson.JSON {
-	arr := []interface{}{}
-	for _, obj := range list {
-		j, err := p.ObjectToJSON(obj)
-		utils.E(err)
-		arr = append(arr, j.Val())
-	}
-	return gson.New(arr)
+	j, err := p.ObjectsToJSON(list)
+	utils.E(err)
+	return j
 }
 
 // MustElementFromNode is similar to ElementFromNode
@@ -405,6 +623,13 @@ func (p *Page) MustElementFromPoint(left, top int) *Element {
 	return el
 }
 
+// MustFrame is similar to Frame
+func (p *Page) MustFrame(nameOrID string) *Page {
+	f, err := p.Frame(nameOrID)
+	utils.E(err)
+	return f
+}
+
 // MustRelease is similar to Release
 func (p *Page) MustRelease(obj *proto.RuntimeRemoteObject) *Page {
 	utils.E(p.Release(obj))
@@ -446,6 +671,12 @@ func (p *Page) MustElement(selector string) *Element {
 	return el
 }
 
+// MustWaitElementThen is similar to WaitElementThen
+func (p *Page) MustWaitElementThen(selector string, action func(*Element) error) *Page {
+	utils.E(p.WaitElementThen(selector, action))
+	return p
+}
+
 // MustElementR is similar to ElementR
 func (p *Page) MustElementR(selector, jsRegex string) *Element {
 	el, err := p.ElementR(selector, jsRegex)
@@ -557,6 +788,12 @@ func (k *Keyboard) MustPress(key rune) *Keyboard {
 	return k
 }
 
+// MustType is similar to Type
+func (k *Keyboard) MustType(text string, delay time.Duration) *Keyboard {
+	utils.E(k.Type(text, delay))
+	return k
+}
+
 // MustInsertText is similar to InsertText
 func (k *Keyboard) MustInsertText(text string) *Keyboard {
 	utils.E(k.InsertText(text))
@@ -600,6 +837,13 @@ func (el *Element) MustDescribe() *proto.DOMNode {
 	return node
 }
 
+// MustTagName is similar to TagName
+func (el *Element) MustTagName() string {
+	name, err := el.TagName()
+	utils.E(err)
+	return name
+}
+
 // MustNodeID is similar to NodeID
 func (el *Element) MustNodeID() proto.DOMNodeID {
 	id, err := el.NodeID()
@@ -685,6 +929,12 @@ func (el *Element) MustInput(text string) *Element {
 	return el
 }
 
+// MustClearInput is similar to ClearInput
+func (el *Element) MustClearInput() *Element {
+	utils.E(el.ClearInput())
+	return el
+}
+
 // MustInputTime is similar to Input
 func (el *Element) MustInputTime(t time.Time) *Element {
 	utils.E(el.InputTime(t))
@@ -788,6 +1038,25 @@ func (el *Element) MustWaitInvisible() *Element {
 	return el
 }
 
+// MustWaitEnabled is similar to WaitEnabled
+func (el *Element) MustWaitEnabled() *Element {
+	utils.E(el.WaitEnabled())
+	return el
+}
+
+// MustChecked is similar to Checked
+func (el *Element) MustChecked() bool {
+	checked, err := el.Checked()
+	utils.E(err)
+	return checked
+}
+
+// MustWaitChecked is similar to WaitChecked
+func (el *Element) MustWaitChecked(checked bool) *Element {
+	utils.E(el.WaitChecked(checked))
+	return el
+}
+
 // MustShape is similar to Shape
 func (el *Element) MustShape() *proto.DOMGetContentQuadsResult {
 	shape, err := el.Shape()
@@ -795,6 +1064,13 @@ func (el *Element) MustShape() *proto.DOMGetContentQuadsResult {
 	return shape
 }
 
+// MustBox is similar to Box
+func (el *Element) MustBox() *proto.DOMRect {
+	box, err := el.Box()
+	utils.E(err)
+	return box
+}
+
 // MustCanvasToImage is similar to CanvasToImage
 func (el *Element) MustCanvasToImage() []byte {
 	bin, err := el.CanvasToImage("", -1)
@@ -841,6 +1117,13 @@ func (el *Element) MustEval(js string, params ...interface{}) gson.JSON {
 	return res.Value
 }
 
+// MustEvaluate is similar to Evaluate
+func (el *Element) MustEvaluate(opts *EvalOptions) *proto.RuntimeRemoteObject {
+	res, err := el.Evaluate(opts)
+	utils.E(err)
+	return res
+}
+
 // MustHas is similar to Has
 func (el *Element) MustHas(selector string) bool {
 	has, _, err := el.Has(selector)
@@ -911,6 +1194,13 @@ func (el *Element) MustPrevious() *Element {
 	return parent
 }
 
+// MustChildren is similar to Children
+func (el *Element) MustChildren() Elements {
+	list, err := el.Children()
+	utils.E(err)
+	return list
+}
+
 // MustElementR is similar to ElementR
 func (el *Element) MustElementR(selector, regex string) *Element {
 	el, err := el.ElementR(selector, regex)
@@ -960,3 +1250,10 @@ func (r *HijackRouter) MustStop() {
 func (h *Hijack) MustLoadResponse() {
 	utils.E(h.LoadResponse(http.DefaultClient, true))
 }
+
+// MustEval is similar to Eval
+func (w *Worker) MustEval(js string) gson.JSON {
+	res, err := w.Eval(js)
+	utils.E(err)
+	return res.Value
+}
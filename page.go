@@ -5,9 +5,17 @@ import (
 	"crypto/md5"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/devices"
 	"github.com/go-rod/rod/lib/js"
 	"github.com/go-rod/rod/lib/proto"
@@ -81,24 +89,155 @@ func (p *Page) Cookies(urls []string) ([]*proto.NetworkCookie, error) {
 }
 
 // SetCookies of the page.
+// If both the URL and Domain of a cookie are empty, the URL of current page will be used.
+// A cookie with SameSite None must be Secure, or an *ErrInsecureCookie will be returned.
 func (p *Page) SetCookies(cookies []*proto.NetworkCookieParam) error {
-	err := proto.NetworkSetCookies{Cookies: cookies}.Call(p)
-	return err
+	err := checkCookies(cookies)
+	if err != nil {
+		return err
+	}
+
+	list := []*proto.NetworkCookieParam{}
+	var info *proto.TargetTargetInfo
+
+	for _, c := range cookies {
+		if c.URL == "" && c.Domain == "" {
+			if info == nil {
+				var err error
+				info, err = p.Info()
+				if err != nil {
+					return err
+				}
+			}
+
+			clone := *c
+			clone.URL = info.URL
+			c = &clone
+		}
+		list = append(list, c)
+	}
+
+	return proto.NetworkSetCookies{Cookies: list}.Call(p)
+}
+
+// SessionState of a page, it's JSON serializable, so that you can persist it and restore it later
+type SessionState struct {
+	// Origin of the storages, such as "https://example.com"
+	Origin         string                 `json:"origin"`
+	Cookies        []*proto.NetworkCookie `json:"cookies"`
+	LocalStorage   map[string]string      `json:"localStorage"`
+	SessionStorage map[string]string      `json:"sessionStorage"`
+}
+
+// SaveState returns the cookies of the page, and the localStorage and sessionStorage of the origin of the page
+func (p *Page) SaveState() (*SessionState, error) {
+	res, err := p.Eval(`location.origin`)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &SessionState{Origin: res.Value.Str()}
+
+	state.Cookies, err = p.Cookies(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	restore := p.EnableDomain(&proto.DOMStorageEnable{})
+	defer restore()
+
+	get := func(local bool) (map[string]string, error) {
+		res, err := proto.DOMStorageGetDOMStorageItems{StorageID: &proto.DOMStorageStorageID{
+			SecurityOrigin: state.Origin,
+			IsLocalStorage: local,
+		}}.Call(p)
+		if err != nil {
+			return nil, err
+		}
+		items := map[string]string{}
+		for _, entry := range res.Entries {
+			items[entry[0]] = entry[1]
+		}
+		return items, nil
+	}
+
+	state.LocalStorage, err = get(true)
+	if err != nil {
+		return nil, err
+	}
+	state.SessionStorage, err = get(false)
+	if err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// RestoreState restores the state from Page.SaveState. The storages are bound to the origin,
+// so the page must be at the same origin of the state, such as:
+//
+//     page.MustNavigate(state.Origin).MustRestoreState(state).MustReload()
+func (p *Page) RestoreState(state *SessionState) error {
+	err := p.SetCookies(proto.CookiesToParams(state.Cookies))
+	if err != nil {
+		return err
+	}
+
+	restore := p.EnableDomain(&proto.DOMStorageEnable{})
+	defer restore()
+
+	set := func(local bool, items map[string]string) error {
+		for k, v := range items {
+			err := proto.DOMStorageSetDOMStorageItem{
+				StorageID: &proto.DOMStorageStorageID{
+					SecurityOrigin: state.Origin,
+					IsLocalStorage: local,
+				},
+				Key:   k,
+				Value: v,
+			}.Call(p)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err = set(true, state.LocalStorage)
+	if err != nil {
+		return err
+	}
+	return set(false, state.SessionStorage)
 }
 
 // SetExtraHeaders whether to always send extra HTTP headers with the requests from this page.
+// The dict is a list of key-value pairs, such as []string{"Authorization", "token", "X-Foo", "bar"}.
+// Call the returned cleanup to remove the headers.
+// The Host header is reserved by the browser, setting it will return an *ErrReservedHeader.
 func (p *Page) SetExtraHeaders(dict []string) (func(), error) {
 	headers := proto.NetworkHeaders{}
 
 	for i := 0; i < len(dict); i += 2 {
+		if strings.EqualFold(dict[i], "Host") {
+			return func() {}, &ErrReservedHeader{dict[i]}
+		}
 		headers[dict[i]] = gson.New(dict[i+1])
 	}
 
-	return p.EnableDomain(&proto.NetworkEnable{}), proto.NetworkSetExtraHTTPHeaders{Headers: headers}.Call(p)
+	restore := p.EnableDomain(&proto.NetworkEnable{})
+
+	cleanup := func() {
+		_ = proto.NetworkSetExtraHTTPHeaders{Headers: proto.NetworkHeaders{}}.Call(p)
+		restore()
+	}
+
+	return cleanup, proto.NetworkSetExtraHTTPHeaders{Headers: headers}.Call(p)
 }
 
 // SetUserAgent (browser brand, accept-language, etc) of the page.
 // If req is nil, a default user agent will be used, a typical mac chrome.
+// Only the UserAgent field is required, the empty optional fields will be omitted.
+// Unlike Page.Emulate, it won't change the viewport.
 func (p *Page) SetUserAgent(req *proto.NetworkSetUserAgentOverride) error {
 	if req == nil {
 		req = &proto.NetworkSetUserAgentOverride{
@@ -110,11 +249,84 @@ func (p *Page) SetUserAgent(req *proto.NetworkSetUserAgentOverride) error {
 	return req.Call(p)
 }
 
+// SetBypassCSP enables/disables the bypass of the Content-Security-Policy of the page, so that the js injected by
+// Page.EvalOnNewDocument or Page.AddScriptTag can run on the pages with strict CSP.
+// It only takes effect on the next navigation, so set it before the Page.Navigate.
+func (p *Page) SetBypassCSP(enable bool) error {
+	return proto.PageSetBypassCSP{Enabled: enable}.Call(p)
+}
+
+// ImagePatterns are the url patterns of the common image and media files, use it with Page.SetBlockedURLs
+var ImagePatterns = []string{
+	"*.png", "*.jpg", "*.jpeg", "*.gif", "*.webp", "*.svg", "*.ico", "*.bmp",
+	"*.mp4", "*.webm", "*.ogg", "*.mp3", "*.wav",
+}
+
+// SetBlockedURLs blocks the requests that match the patterns, wildcards ('*') are allowed.
+// The blocked requests fail immediately, it's lighter than the Page.HijackRequests if you only need to drop requests.
+// Such as to block the images and media files:
+//
+//     page.MustSetBlockedURLs(rod.ImagePatterns...)
+//
+// Set the patterns to empty to unblock all of them.
+func (p *Page) SetBlockedURLs(patterns []string) error {
+	if patterns == nil {
+		patterns = []string{}
+	}
+
+	err := proto.NetworkEnable{}.Call(p)
+	if err != nil {
+		return err
+	}
+
+	return proto.NetworkSetBlockedURLs{Urls: patterns}.Call(p)
+}
+
+// ThrottleNetwork emulates the network conditions, such as page.ThrottleNetwork(devices.Slow3G) .
+// Use devices.NoThrottling to clear the throttling.
+func (p *Page) ThrottleNetwork(conditions proto.NetworkEmulateNetworkConditions) error {
+	err := proto.NetworkEnable{}.Call(p)
+	if err != nil {
+		return err
+	}
+
+	return conditions.Call(p)
+}
+
+// Offline emulates the internet disconnection, it will clear the network throttling set by Page.ThrottleNetwork .
+func (p *Page) Offline(enable bool) error {
+	conditions := devices.NoThrottling
+	conditions.Offline = enable
+	return p.ThrottleNetwork(conditions)
+}
+
+// ThrottleCPU slows down the CPU by the rate, such as 4 means 4x slower. Set it to 1 to clear the throttling.
+func (p *Page) ThrottleCPU(rate float64) error {
+	return proto.EmulationSetCPUThrottlingRate{Rate: rate}.Call(p)
+}
+
 // Navigate to the url. If the url is empty, "about:blank" will be used.
 // It will return immediately after the server responds the http header.
 func (p *Page) Navigate(url string) error {
-	if url == "" {
-		url = "about:blank"
+	return p.NavigateWithOptions(&proto.PageNavigate{URL: url})
+}
+
+// NavigateWithOptions is similar to Navigate, but you can set options such as the referrer and transition type.
+// It will return immediately after the server responds the http header, to wait for the load use something like:
+//
+//     wait := page.WaitNavigation(proto.PageLifecycleEventNameLoad)
+//     page.MustNavigateWithOptions(opts)
+//     wait()
+//
+// If opts is nil, it will navigate to "about:blank".
+func (p *Page) NavigateWithOptions(opts *proto.PageNavigate) error {
+	if opts == nil {
+		opts = &proto.PageNavigate{}
+	}
+
+	req := *opts
+	if req.URL == "" {
+		req.URL = "about:blank"
 	}
 
 	err := p.StopLoading()
@@ -122,7 +334,7 @@ func (p *Page) Navigate(url string) error {
 		return err
 	}
 
-	res, err := proto.PageNavigate{URL: url}.Call(p)
+	res, err := req.Call(p)
 	if err != nil {
 		return err
 	}
@@ -133,21 +345,23 @@ func (p *Page) Navigate(url string) error {
 	return p.root.updateJSCtxID()
 }
 
-// NavigateBack history.
+// NavigateBack history. It won't wait for the navigation, because a same-document history entry won't load,
+// use Page.WaitNavigation if you need to wait.
 func (p *Page) NavigateBack() error {
 	// Not using cdp API because it doesn't work for iframe
 	_, err := p.Evaluate(Eval(`history.back()`).ByUser())
 	return err
 }
 
-// NavigateForward history.
+// NavigateForward history. It won't wait for the navigation, because a same-document history entry won't load,
+// use Page.WaitNavigation if you need to wait.
 func (p *Page) NavigateForward() error {
 	// Not using cdp API because it doesn't work for iframe
 	_, err := p.Evaluate(Eval(`history.forward()`).ByUser())
 	return err
 }
 
-// Reload page.
+// Reload page, it waits until the frame of the page is navigated.
 func (p *Page) Reload() error {
 	p, cancel := p.WithCancel()
 	defer cancel()
@@ -166,6 +380,22 @@ func (p *Page) Reload() error {
 	return p.updateJSCtxID()
 }
 
+// SetDocumentContent sets the html of the document of the page, it's handy to render html fragments without a server:
+//
+//     page.MustNavigate("").MustSetDocumentContent("<button>ok</button>")
+func (p *Page) SetDocumentContent(html string) error {
+	return proto.PageSetDocumentContent{FrameID: p.FrameID, HTML: html}.Call(p)
+}
+
+// HTML of the document of the page
+func (p *Page) HTML() (string, error) {
+	res, err := p.Eval(`document.documentElement.outerHTML`)
+	if err != nil {
+		return "", err
+	}
+	return res.Value.String(), nil
+}
+
 func (p *Page) getWindowID() (proto.BrowserWindowID, error) {
 	res, err := proto.BrowserGetWindowForTarget{TargetID: p.TargetID}.Call(p)
 	if err != nil {
@@ -200,7 +430,10 @@ func (p *Page) SetWindow(bounds *proto.BrowserBounds) error {
 	return err
 }
 
-// SetViewport overrides the values of device screen dimensions
+// SetViewport overrides the values of device screen dimensions of the page, it can be called anytime
+// to resize the page, such as for responsive screenshots.
+// If params is nil, the override will be cleared and the page restores the window default.
+// The default viewport a new page gets is set via Browser.DefaultDevice .
 func (p *Page) SetViewport(params *proto.EmulationSetDeviceMetricsOverride) error {
 	if params == nil {
 		return proto.EmulationClearDeviceMetricsOverride{}.Call(p)
@@ -208,6 +441,92 @@ func (p *Page) SetViewport(params *proto.EmulationSetDeviceMetricsOverride) erro
 	return params.Call(p)
 }
 
+// SetGeolocation overrides the geolocation of the page, latitude is in [-90, 90], longitude is in [-180, 180].
+// The geolocation permission will be granted to the browser context of the page, so no prompt will show up.
+// Use proto.EmulationClearGeolocationOverride to clear the override.
+func (p *Page) SetGeolocation(latitude, longitude, accuracy float64) error {
+	if !(latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180 && accuracy >= 0) {
+		return &ErrInvalidGeolocation{latitude, longitude, accuracy}
+	}
+
+	err := proto.BrowserGrantPermissions{
+		Permissions:      []proto.BrowserPermissionType{proto.BrowserPermissionTypeGeolocation},
+		BrowserContextID: p.browser.BrowserContextID,
+	}.Call(p.browser)
+	if err != nil {
+		return err
+	}
+
+	return proto.EmulationSetGeolocationOverride{
+		Latitude:  latitude,
+		Longitude: longitude,
+		Accuracy:  accuracy,
+	}.Call(p)
+}
+
+// SetTimezone overrides the timezone of the page, such as "America/New_York". Empty string clears the override.
+// Timezone list: https://en.wikipedia.org/wiki/List_of_tz_database_time_zones
+func (p *Page) SetTimezone(id string) error {
+	return proto.EmulationSetTimezoneOverride{TimezoneID: id}.Call(p)
+}
+
+// SetLocale overrides the ICU locale of the page, such as "fr_FR". Empty string clears the override.
+// Use it with Page.SetUserAgent to set the Accept-Language header too.
+func (p *Page) SetLocale(locale string) error {
+	return proto.EmulationSetLocaleOverride{Locale: locale}.Call(p)
+}
+
+// ReadClipboard returns the text of the clipboard, check Page.WriteClipboard for the requirements.
+func (p *Page) ReadClipboard() (string, error) {
+	err := p.prepareClipboard()
+	if err != nil {
+		return "", err
+	}
+
+	res, err := p.Evaluate(Eval(`() => navigator.clipboard.readText()`).ByPromise())
+	if err != nil {
+		return "", err
+	}
+	return res.Value.Str(), nil
+}
+
+// WriteClipboard writes the text to the clipboard. The clipboard permissions will be granted to the browser context
+// of the page, and the page will be brought to front, because the clipboard is only available for the focused page.
+// The clipboard is only available in secure contexts, such as https or localhost, else an *ErrNotSecureContext
+// will be returned.
+func (p *Page) WriteClipboard(text string) error {
+	err := p.prepareClipboard()
+	if err != nil {
+		return err
+	}
+
+	_, err = p.Evaluate(Eval(`t => navigator.clipboard.writeText(t)`, text).ByPromise())
+	return err
+}
+
+func (p *Page) prepareClipboard() error {
+	res, err := p.Eval(`() => window.isSecureContext ? "" : location.origin`)
+	if err != nil {
+		return err
+	}
+	if origin := res.Value.Str(); origin != "" {
+		return &ErrNotSecureContext{origin}
+	}
+
+	err = proto.BrowserGrantPermissions{
+		Permissions: []proto.BrowserPermissionType{
+			proto.BrowserPermissionTypeClipboardReadWrite,
+			proto.BrowserPermissionTypeClipboardSanitizedWrite,
+		},
+		BrowserContextID: p.browser.BrowserContextID,
+	}.Call(p.browser)
+	if err != nil {
+		return err
+	}
+
+	return proto.PageBringToFront{}.Call(p)
+}
+
 // Emulate the device, such as iPhone9. If device is devices.Clear, it will clear the override.
 func (p *Page) Emulate(device devices.Device) error {
 	err := p.SetViewport(device.Metrics())
@@ -223,16 +542,52 @@ func (p *Page) Emulate(device devices.Device) error {
 	return p.SetUserAgent(device.UserAgent())
 }
 
+// EmulateMedia overrides the media type, such as "print", and the media features for the css media queries, such as:
+//
+//     page.MustEmulateMedia("", map[string]string{"prefers-reduced-motion": "reduce"})
+//
+// Each call replaces the previous overrides, use an empty media and nil features to clear them.
+func (p *Page) EmulateMedia(media string, features map[string]string) error {
+	names := []string{}
+	for name := range features {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := []*proto.EmulationMediaFeature{}
+	for _, name := range names {
+		list = append(list, &proto.EmulationMediaFeature{Name: name, Value: features[name]})
+	}
+
+	return proto.EmulationSetEmulatedMedia{Media: media, Features: list}.Call(p)
+}
+
+// DarkMode is a shortcut to emulate the media feature "prefers-color-scheme: dark" via Page.EmulateMedia
+func (p *Page) DarkMode() error {
+	return p.EmulateMedia("", map[string]string{"prefers-color-scheme": "dark"})
+}
+
 // StopLoading forces the page stop navigation and pending resource fetches.
 func (p *Page) StopLoading() error {
 	return proto.PageStopLoading{}.Call(p)
 }
 
 // Close tries to close page, running its beforeunload hooks, if any.
+// The browser will be kept alive. Closing a closed page is a no-op, other calls to it will return an *ErrTargetClosed.
+// For the page from Browser.PageFromSession, it won't wait for the page to be closed.
 func (p *Page) Close() error {
+	if p.TargetID == "" {
+		return proto.PageClose{}.Call(p)
+	}
+
 	p.browser.targetsLock.Lock()
 	defer p.browser.targetsLock.Unlock()
 
+	// the cache is removed when the target is destroyed
+	if p.browser.loadCachedPage(p.TargetID) == nil {
+		return nil
+	}
+
 	success := true
 	ctx, cancel := context.WithCancel(p.ctx)
 	defer cancel()
@@ -277,6 +632,12 @@ func (p *Page) Close() error {
 //     go wait()
 //     page.MustElement("button").MustClick()
 //
+// To handle all the dialogs of the page by their type or message, use Page.EachEvent:
+//
+//     go page.EachEvent(func(e *proto.PageJavascriptDialogOpening) {
+//         _ = proto.PageHandleJavaScriptDialog{Accept: e.Type == proto.PageDialogTypeAlert}.Call(page)
+//     })()
+//
 func (p *Page) HandleDialog(accept bool, promptText string) (wait func() error) {
 	restore := p.EnableDomain(&proto.PageEnable{})
 
@@ -293,8 +654,16 @@ func (p *Page) HandleDialog(accept bool, promptText string) (wait func() error)
 	}
 }
 
-// Screenshot options: https://chromedevtools.github.io/devtools-protocol/tot/Page#method-captureScreenshot
+// Screenshot captures the screenshot of current page, the req is used to set the format, quality, clip, etc.
+// If req is nil, a png of the current viewport will be captured.
+// If fullpage is true, the viewport will be resized to the size of the whole page content during the capture,
+// and restored afterwards even if the capture fails.
+// Options: https://chromedevtools.github.io/devtools-protocol/tot/Page#method-captureScreenshot
 func (p *Page) Screenshot(fullpage bool, req *proto.PageCaptureScreenshot) ([]byte, error) {
+	if req == nil {
+		req = &proto.PageCaptureScreenshot{}
+	}
+
 	if fullpage {
 		metrics, err := proto.PageGetLayoutMetrics{}.Call(p)
 		if err != nil {
@@ -329,10 +698,27 @@ func (p *Page) Screenshot(fullpage bool, req *proto.PageCaptureScreenshot) ([]by
 	return shot.Data, nil
 }
 
-// PDF prints page as PDF
+// CaptureSnapshot of the page as a MHTML document, it bundles the DOM and the resources into a single file
+// that can be opened by the browser offline. Unlike the screenshot, it preserves the text and structure of the page.
+func (p *Page) CaptureSnapshot() (string, error) {
+	res, err := proto.PageCaptureSnapshot{Format: proto.PageCaptureSnapshotFormatMhtml}.Call(p)
+	if err != nil {
+		return "", err
+	}
+	return res.Data, nil
+}
+
+// PDF prints page as PDF, the req is used to set the layout, paper size, margins, header and footer templates, etc.
+// If req is nil, the default options will be used. The returned reader streams the file data from the browser.
+// Usually the browser only supports it in headless mode, or the browser will respond an error.
 func (p *Page) PDF(req *proto.PagePrintToPDF) (*StreamReader, error) {
-	req.TransferMode = proto.PagePrintToPDFTransferModeReturnAsStream
-	res, err := req.Call(p)
+	opts := proto.PagePrintToPDF{}
+	if req != nil {
+		opts = *req
+	}
+
+	opts.TransferMode = proto.PagePrintToPDFTransferModeReturnAsStream
+	res, err := opts.Call(p)
 	if err != nil {
 		return nil, err
 	}
@@ -340,11 +726,72 @@ func (p *Page) PDF(req *proto.PagePrintToPDF) (*StreamReader, error) {
 	return NewStreamReader(p, res.Stream), nil
 }
 
+// StartTracing with the categories, such as "devtools.timeline". If the categories is empty,
+// the default categories of the browser will be used. Use Page.StopTracing to get the trace.
+func (p *Page) StartTracing(categories []string) error {
+	return proto.TracingStart{
+		TransferMode: proto.TracingStartTransferModeReturnAsStream,
+		TraceConfig:  &proto.TracingTraceConfig{IncludedCategories: categories},
+	}.Call(p)
+}
+
+// StopTracing and returns the trace as json, it can be loaded by chrome://tracing or the Performance panel of devtools.
+// It supports both the ReturnAsStream and ReportEvents transfer modes of proto.TracingStart.
+func (p *Page) StopTracing() ([]byte, error) {
+	events := []map[string]gson.JSON{}
+	var complete *proto.TracingTracingComplete
+
+	wait := p.EachEvent(func(e *proto.TracingDataCollected) {
+		events = append(events, e.Value...)
+	}, func(e *proto.TracingTracingComplete) bool {
+		complete = e
+		return true
+	})
+
+	err := proto.TracingEnd{}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	wait()
+
+	if complete == nil {
+		return nil, p.ctx.Err()
+	}
+
+	if complete.Stream == "" {
+		return json.Marshal(map[string]interface{}{"traceEvents": events})
+	}
+
+	defer func() { _ = proto.IOClose{Handle: complete.Stream}.Call(p) }()
+	return ioutil.ReadAll(NewStreamReader(p, complete.Stream))
+}
+
+// GetResourceTree returns the frame tree of the page along with the resources the browser has loaded
+func (p *Page) GetResourceTree() (*proto.PageFrameResourceTree, error) {
+	res, err := proto.PageGetResourceTree{}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+	return res.FrameTree, nil
+}
+
 // GetResource content by the url. Such as image, css, html, etc.
-// Use the proto.PageGetResourceTree to list all the resources.
+// The content is read from the browser cache, use GetResourceTree to list all the resources.
+// If the url isn't in the resource tree, an *ErrResourceNotFound will be returned.
 func (p *Page) GetResource(url string) ([]byte, error) {
+	tree, err := p.GetResourceTree()
+	if err != nil {
+		return nil, err
+	}
+
+	frameID := findResource(tree, url)
+	if frameID == "" {
+		return nil, &ErrResourceNotFound{url}
+	}
+
 	res, err := proto.PageGetResourceContent{
-		FrameID: p.FrameID,
+		FrameID: frameID,
 		URL:     url,
 	}.Call(p)
 	if err != nil {
@@ -364,22 +811,102 @@ func (p *Page) GetResource(url string) ([]byte, error) {
 	return bin, nil
 }
 
-// WaitOpen waits for the next new page opened by the current one
+// Metrics of the page's run-time, such as Timestamp, JSHeapUsedSize, Nodes, and LayoutCount.
+// To measure an interaction, diff the metrics before and after it, such as:
+//
+//     before := page.MustMetrics()
+//     page.MustElement("button").MustClick()
+//     after := page.MustMetrics()
+//     fmt.Println(after["JSHeapUsedSize"] - before["JSHeapUsedSize"])
+func (p *Page) Metrics() (map[string]float64, error) {
+	restore := p.EnableDomain(&proto.PerformanceEnable{})
+	defer restore()
+
+	res, err := proto.PerformanceGetMetrics{}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := map[string]float64{}
+	for _, m := range res.Metrics {
+		metrics[m.Name] = m.Value
+	}
+	return metrics, nil
+}
+
+// findResource returns the id of the frame that owns the resource
+func findResource(tree *proto.PageFrameResourceTree, url string) proto.PageFrameID {
+	if tree.Frame.URL == url {
+		return tree.Frame.ID
+	}
+	for _, r := range tree.Resources {
+		if r.URL == url {
+			return tree.Frame.ID
+		}
+	}
+	for _, child := range tree.ChildFrames {
+		if id := findResource(child, url); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// WaitOpen waits for the next new page opened by the current one, such as by window.open or a link with target="_blank".
+// Call it before the action that opens the page.
 func (p *Page) WaitOpen() func() (*Page, error) {
 	var targetID proto.TargetTargetID
 
 	b := p.browser.Context(p.ctx)
 	wait := b.EachEvent(func(e *proto.TargetTargetCreated) bool {
-		targetID = e.TargetInfo.TargetID
-		return e.TargetInfo.OpenerID == p.TargetID
+		if e.TargetInfo.OpenerID == p.TargetID {
+			targetID = e.TargetInfo.TargetID
+			return true
+		}
+		return false
 	})
 
 	return func() (*Page, error) {
 		wait()
+		if targetID == "" {
+			return nil, p.ctx.Err()
+		}
 		return b.PageFromTarget(targetID)
 	}
 }
 
+// WaitFileChooser waits for the next file chooser dialog of the page, such as a custom upload button that clicks
+// a hidden file input. Call it before the action that opens the dialog. The dialog will be intercepted,
+// use the returned file input element to set the files:
+//
+//     wait := page.MustWaitFileChooser()
+//     page.MustElement("button").MustClick()
+//     wait().MustSetFiles("a.txt")
+func (p *Page) WaitFileChooser() func() (*Element, error) {
+	err := proto.PageSetInterceptFileChooserDialog{Enabled: true}.Call(p)
+	if err != nil {
+		return func() (*Element, error) { return nil, err }
+	}
+
+	e := &proto.PageFileChooserOpened{}
+	wait := p.WaitEvent(e)
+
+	return func() (*Element, error) {
+		defer func() { _ = proto.PageSetInterceptFileChooserDialog{Enabled: false}.Call(p) }()
+
+		wait()
+		if e.BackendNodeID == 0 {
+			return nil, p.ctx.Err()
+		}
+
+		node, err := proto.DOMResolveNode{BackendNodeID: e.BackendNodeID}.Call(p)
+		if err != nil {
+			return nil, err
+		}
+		return p.ElementFromObject(node.Object), nil
+	}
+}
+
 // WaitPauseOpen waits for a page opened by the current page, before opening pause the js execution.
 // Because the js will be paused, you should put the code that triggers it in a goroutine.
 func (p *Page) WaitPauseOpen() (func() (*Page, func() error, error), error) {
@@ -417,21 +944,198 @@ func (p *Page) EachEvent(callbacks ...interface{}) (wait func()) {
 
 // WaitEvent waits for the next event for one time. It will also load the data into the event object.
 func (p *Page) WaitEvent(e proto.Event) (wait func()) {
-	return p.browser.Context(p.ctx).waitEvent(p.SessionID, e)
+	return p.browser.Context(p.ctx).waitEvent(p.SessionID, e, nil)
 }
 
-// WaitNavigation wait for a page lifecycle event when navigating.
-// Usually you will wait for proto.PageLifecycleEventNameNetworkAlmostIdle
+// WaitEventBy is similar to Browser.WaitEventBy, but only catches events for current page.
+func (p *Page) WaitEventBy(e proto.Event, match func() bool) (wait func()) {
+	return p.browser.Context(p.ctx).waitEvent(p.SessionID, e, match)
+}
+
+// WaitNavigation wait for a page lifecycle event of the current frame when navigating,
+// events from sub frames are ignored.
+// Usually you will wait for proto.PageLifecycleEventNameNetworkAlmostIdle .
+// Call it before the action that triggers the navigation, such as a click, to avoid missing the event:
+//
+//     wait := page.WaitNavigation(proto.PageLifecycleEventNameLoad)
+//     page.MustElement("a").MustClick()
+//     wait()
+//
+// To observe all the lifecycle events, call proto.PageSetLifecycleEventsEnabled and use Page.EachEvent to
+// listen to proto.PageLifecycleEvent, it will stay enabled after the wait.
 func (p *Page) WaitNavigation(name proto.PageLifecycleEventName) func() {
-	_ = proto.PageSetLifecycleEventsEnabled{Enabled: true}.Call(p)
+	state := &proto.PageSetLifecycleEventsEnabled{}
+	enabled := p.LoadState(state) && state.Enabled
+	if !enabled {
+		_ = proto.PageSetLifecycleEventsEnabled{Enabled: true}.Call(p)
+	}
 
 	wait := p.EachEvent(func(e *proto.PageLifecycleEvent) bool {
-		return e.Name == name
+		return e.FrameID == p.FrameID && e.Name == name
 	})
 
 	return func() {
 		wait()
-		_ = proto.PageSetLifecycleEventsEnabled{Enabled: false}.Call(p)
+		if !enabled {
+			_ = proto.PageSetLifecycleEventsEnabled{Enabled: false}.Call(p)
+		}
+	}
+}
+
+// WaitRequest returns a wait function that waits for the next request that its url matches the regexp pattern.
+// Call it before the action that triggers the request:
+//
+//     wait := page.WaitRequest(`/api/items`)
+//     page.MustElement("button").MustClick()
+//     req, err := wait()
+//
+// The err will be the error of the page context if it's canceled or timed out before the request is sent.
+func (p *Page) WaitRequest(pattern string) func() (*proto.NetworkRequest, error) {
+	reg, err := regexp.Compile(pattern)
+	if err != nil {
+		return func() (*proto.NetworkRequest, error) { return nil, err }
+	}
+
+	restore := p.EnableDomain(&proto.NetworkEnable{})
+
+	var req *proto.NetworkRequest
+	wait := p.EachEvent(func(e *proto.NetworkRequestWillBeSent) bool {
+		if reg.MatchString(e.Request.URL) {
+			req = e.Request
+			return true
+		}
+		return false
+	})
+
+	return func() (*proto.NetworkRequest, error) {
+		defer restore()
+		wait()
+		if req == nil {
+			return nil, p.ctx.Err()
+		}
+		return req, nil
+	}
+}
+
+// WaitResponse is similar to WaitRequest, but it waits until the response of the request is loaded,
+// then returns the response and its body. If the request fails, an *ErrLoadingFailed will be returned.
+// If the request is loaded without a response, an *ErrNoResponse will be returned.
+func (p *Page) WaitResponse(pattern string) func() (*proto.NetworkResponse, []byte, error) {
+	reg, err := regexp.Compile(pattern)
+	if err != nil {
+		return func() (*proto.NetworkResponse, []byte, error) { return nil, nil, err }
+	}
+
+	restore := p.EnableDomain(&proto.NetworkEnable{})
+
+	var id proto.NetworkRequestID
+	var res *proto.NetworkResponse
+	var failed *proto.NetworkLoadingFailed
+
+	wait := p.EachEvent(func(e *proto.NetworkRequestWillBeSent) {
+		if id == "" && reg.MatchString(e.Request.URL) {
+			id = e.RequestID
+		}
+	}, func(e *proto.NetworkResponseReceived) {
+		if e.RequestID == id {
+			res = e.Response
+		}
+	}, func(e *proto.NetworkLoadingFinished) bool {
+		return e.RequestID == id
+	}, func(e *proto.NetworkLoadingFailed) bool {
+		if e.RequestID == id {
+			failed = e
+			return true
+		}
+		return false
+	})
+
+	return func() (*proto.NetworkResponse, []byte, error) {
+		defer restore()
+		wait()
+
+		if failed != nil {
+			return nil, nil, &ErrLoadingFailed{failed}
+		}
+		if err := p.ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		if res == nil {
+			return nil, nil, &ErrNoResponse{id}
+		}
+
+		// get the body before the browser evicts it from the cache
+		body, err := proto.NetworkGetResponseBody{RequestID: id}.Call(p)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if body.Base64Encoded {
+			bin, err := base64.StdEncoding.DecodeString(body.Body)
+			return res, bin, err
+		}
+		return res, []byte(body.Body), nil
+	}
+}
+
+// WaitDownload returns a wait function that waits until the next download of the page completes, then returns
+// the path of the file. The file will be saved to the dir and named by the GUID of the download:
+//
+//     wait := page.MustWaitDownload(dir)
+//     page.MustElement("a").MustClick()
+//     path := wait()
+//
+// If the download is canceled, an *ErrDownloadCanceled will be returned.
+// The download behavior is set for the whole browser context, after the wait it will be restored to the previous one.
+func (p *Page) WaitDownload(dir string) func() (string, error) {
+	prev := &proto.BrowserSetDownloadBehavior{}
+	hasPrev := p.browser.LoadState("", prev)
+
+	err := proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorAllowAndName,
+		BrowserContextID: p.browser.BrowserContextID,
+		DownloadPath:     dir,
+	}.Call(p.browser)
+	if err != nil {
+		return func() (string, error) { return "", err }
+	}
+
+	var begin *proto.PageDownloadWillBegin
+	var state proto.PageDownloadProgressState
+
+	wait := p.EachEvent(func(e *proto.PageDownloadWillBegin) {
+		if begin == nil {
+			begin = e
+		}
+	}, func(e *proto.PageDownloadProgress) bool {
+		if begin == nil || e.GUID != begin.GUID {
+			return false
+		}
+		state = e.State
+		return state != proto.PageDownloadProgressStateInProgress
+	})
+
+	return func() (string, error) {
+		defer func() {
+			if hasPrev {
+				_ = prev.Call(p.browser)
+				return
+			}
+			_ = proto.BrowserSetDownloadBehavior{
+				Behavior:         proto.BrowserSetDownloadBehaviorBehaviorDefault,
+				BrowserContextID: p.browser.BrowserContextID,
+			}.Call(p.browser)
+		}()
+
+		wait()
+
+		switch state {
+		case proto.PageDownloadProgressStateCompleted:
+			return filepath.Join(dir, begin.GUID), nil
+		case proto.PageDownloadProgressStateCanceled:
+			return "", &ErrDownloadCanceled{begin}
+		}
+		return "", p.ctx.Err()
 	}
 }
 
@@ -485,12 +1189,33 @@ func (p *Page) WaitRequestIdle(d time.Duration, includes, excludes []string) fun
 }
 
 // WaitIdle waits until the next window.requestIdleCallback is called.
+// The timeout is the max time to wait for the callback, check the timeout option of the window.requestIdleCallback.
 func (p *Page) WaitIdle(timeout time.Duration) (err error) {
-	_, err = p.Evaluate(EvalHelper(js.WaitIdle, timeout.Seconds()).ByPromise())
+	_, err = p.Evaluate(EvalHelper(js.WaitIdle, timeout.Milliseconds()).ByPromise())
 	return err
 }
 
+// WaitSettled waits until the page is done doing stuff, it's the combination of Page.WaitRequestIdle and Page.WaitIdle.
+// It waits until there's no new request for the requestIdle duration, then waits for the next window.requestIdleCallback
+// with the jsIdle as the timeout. Set any of them to zero to skip the related wait.
+// The requests that are sent before the call are not tracked, use Page.WaitRequestIdle before the action to track them.
+func (p *Page) WaitSettled(requestIdle, jsIdle time.Duration) error {
+	if requestIdle > 0 {
+		p.WaitRequestIdle(requestIdle, nil, nil)()
+		if p.ctx.Err() != nil {
+			return p.ctx.Err()
+		}
+	}
+
+	if jsIdle > 0 {
+		return p.WaitIdle(jsIdle)
+	}
+	return nil
+}
+
 // WaitLoad waits for the `window.onload` event, it returns immediately if the event is already fired.
+// To wait for other loading stages, such as DOMContentLoaded or network idle, use Page.WaitNavigation with
+// the related proto.PageLifecycleEventName, or Page.WaitRequestIdle for a custom idle duration.
 func (p *Page) WaitLoad() error {
 	_, err := p.Evaluate(EvalHelper(js.WaitLoad).ByPromise())
 	return err
@@ -513,6 +1238,10 @@ func (p *Page) AddStyleTag(url, content string) error {
 }
 
 // EvalOnNewDocument Evaluates given script in every frame upon creation (before loading frame's scripts).
+// It persists across the navigations of the page until the remove is called, such as to stub a global:
+//
+//     remove := page.MustEvalOnNewDocument(`window.alert = () => {}`)
+//     defer remove()
 func (p *Page) EvalOnNewDocument(js string) (remove func() error, err error) {
 	res, err := proto.PageAddScriptToEvaluateOnNewDocument{Source: js}.Call(p)
 	if err != nil {
@@ -528,7 +1257,9 @@ func (p *Page) EvalOnNewDocument(js string) (remove func() error, err error) {
 	return
 }
 
-// Wait js function until it returns true
+// Wait js function until it returns true, the value must be the boolean true, such as `() => !!window.dataLoaded`.
+// It polls the js with the Sleeper of the page as the backoff, use Page.Timeout or Page.Context to limit the time.
+// The this is optional, it's the "this" of the js function.
 func (p *Page) Wait(this *proto.RuntimeRemoteObject, js string, params []interface{}) error {
 	removeTrace := func() {}
 	defer removeTrace()
@@ -566,6 +1297,20 @@ func (p *Page) ObjectToJSON(obj *proto.RuntimeRemoteObject) (gson.JSON, error) {
 	return res.Result.Value, nil
 }
 
+// ObjectsToJSON is similar to ObjectToJSON, but for a list of objects,
+// such as the Args of proto.RuntimeConsoleAPICalled when you listen to the console output of the page.
+func (p *Page) ObjectsToJSON(list []*proto.RuntimeRemoteObject) (gson.JSON, error) {
+	arr := []interface{}{}
+	for _, obj := range list {
+		j, err := p.ObjectToJSON(obj)
+		if err != nil {
+			return gson.New(nil), err
+		}
+		arr = append(arr, j.Val())
+	}
+	return gson.New(arr), nil
+}
+
 // ElementFromObject creates an Element from the remote object id.
 func (p *Page) ElementFromObject(obj *proto.RuntimeRemoteObject) *Element {
 	// If the element is in an iframe, we need the jsCtxID to inject helper.js to the correct context.
@@ -621,6 +1366,45 @@ func (p *Page) ElementFromPoint(x, y int) (*Element, error) {
 	return p.ElementFromNode(node.NodeID)
 }
 
+// Frame returns the iframe of the page whose frame name or frame id equals nameOrID, nested iframes are included.
+// It won't wait for the iframe to be attached, if no iframe matches, an *ErrElementNotFound will be returned.
+// To get the iframe from its element, use Element.Frame .
+func (p *Page) Frame(nameOrID string) (*Page, error) {
+	tree, err := proto.PageGetFrameTree{}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	id := findFrame(tree.FrameTree.ChildFrames, nameOrID)
+	if id == "" {
+		return nil, &ErrElementNotFound{}
+	}
+
+	owner, err := proto.DOMGetFrameOwner{FrameID: id}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := proto.DOMResolveNode{BackendNodeID: owner.BackendNodeID}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ElementFromObject(node.Object).Frame()
+}
+
+func findFrame(list []*proto.PageFrameTree, nameOrID string) proto.PageFrameID {
+	for _, tree := range list {
+		if tree.Frame.Name == nameOrID || string(tree.Frame.ID) == nameOrID {
+			return tree.Frame.ID
+		}
+		if id := findFrame(tree.ChildFrames, nameOrID); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
 // Release the remote object. Usually, you don't need to call it.
 // When a page is closed or reloaded, all remote objects will be released automatically.
 // It's useful if the page never closes or reloads.
@@ -629,9 +1413,16 @@ func (p *Page) Release(obj *proto.RuntimeRemoteObject) error {
 	return err
 }
 
-// Call implements the proto.Client
+// Call implements the proto.Client. The sessionID won't be set automatically, an empty one means the call is
+// sent to the browser, pass the Page.SessionID to send it to the page. The proto types, such as proto.PageReload{}.Call(page),
+// will handle it for you.
+// If the page is closed, an *ErrTargetClosed will be returned.
 func (p *Page) Call(ctx context.Context, sessionID, methodName string, params interface{}) (res []byte, err error) {
-	return p.browser.Call(ctx, sessionID, methodName, params)
+	res, err = p.browser.Call(ctx, sessionID, methodName, params)
+	if errors.Is(err, cdp.ErrSessionNotFound) {
+		return nil, &ErrTargetClosed{p.TargetID, err}
+	}
+	return
 }
 
 // Event of the page
@@ -96,7 +96,9 @@ func (e *EvalOptions) formatToJSFunc() string {
 	return fmt.Sprintf(`function() { return %s }`, js)
 }
 
-// Eval is just a shortcut for Page.Evaluate
+// Eval is just a shortcut for Page.Evaluate with AwaitPromise enabled.
+// The js can be a function definition such as `(a, b) => a + b`, the jsArgs will be passed to it.
+// If the js throws, the err will be an ErrEval that contains the exception details.
 func (p *Page) Eval(js string, jsArgs ...interface{}) (*proto.RuntimeRemoteObject, error) {
 	return p.Evaluate(Eval(js, jsArgs...).ByPromise())
 }
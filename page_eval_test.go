@@ -13,13 +13,21 @@ import (
 func (t T) PageEvalOnNewDocument() {
 	p := t.newPage("")
 
-	p.MustEvalOnNewDocument(`window.rod = 'ok'`)
+	remove := p.MustEvalOnNewDocument(`window.rod = 'ok'`)
 
 	// to activate the script
 	p.MustNavigate(t.blank())
 
 	t.Eq(p.MustEval("rod").String(), "ok")
 
+	// it persists across navigations until it's removed
+	p.MustNavigate(t.blank())
+	t.Eq(p.MustEval("rod").String(), "ok")
+
+	remove()
+	p.MustNavigate(t.blank())
+	t.True(p.MustEval("window.rod").Nil())
+
 	t.Panic(func() {
 		t.mc.stubErr(1, proto.PageAddScriptToEvaluateOnNewDocument{})
 		p.MustEvalOnNewDocument(`1`)
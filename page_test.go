@@ -3,21 +3,28 @@ package rod_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"image/jpeg"
 	"image/png"
+	"io/ioutil"
+	"math"
 	"net/http"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/devices"
 	"github.com/go-rod/rod/lib/input"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
 	"github.com/ysmood/got"
+	"github.com/ysmood/gson"
 )
 
 func (t T) GetPageURL() {
@@ -60,6 +67,125 @@ func (t T) SetCookies() {
 		t.mc.stubErr(1, proto.NetworkGetCookies{})
 		page.MustCookies()
 	})
+
+	// use the current page url by default
+	page.MustSetCookies(&proto.NetworkCookieParam{Name: "cookie-c", Value: "3"})
+	cookies = page.MustCookies()
+	t.Len(cookies, 1)
+	t.Eq("3", cookies[0].Value)
+
+	t.Panic(func() {
+		t.mc.stubErr(1, proto.TargetGetTargetInfo{})
+		page.MustSetCookies(&proto.NetworkCookieParam{Name: "cookie-d", Value: "4"})
+	})
+
+	err := page.SetCookies([]*proto.NetworkCookieParam{{
+		Name:     "cookie-e",
+		SameSite: proto.NetworkCookieSameSiteNone,
+	}})
+	t.Is(err, &rod.ErrInsecureCookie{})
+
+	t.E(proto.NetworkClearBrowserCookies{}.Call(page))
+}
+
+func (t T) PageEmulateMedia() {
+	p := t.newPage(t.blank())
+	match := func(query string) bool {
+		return p.MustEval(`q => matchMedia(q).matches`, query).Bool()
+	}
+
+	p.MustDarkMode()
+	t.True(match("(prefers-color-scheme: dark)"))
+
+	p.MustEmulateMedia("print", map[string]string{
+		"prefers-color-scheme":   "light",
+		"prefers-reduced-motion": "reduce",
+	})
+	t.True(match("print"))
+	t.True(match("(prefers-color-scheme: light)"))
+	t.True(match("(prefers-reduced-motion: reduce)"))
+
+	p.MustEmulateMedia("", nil)
+	t.True(match("screen"))
+	t.False(match("(prefers-reduced-motion: reduce)"))
+}
+
+func (t T) PageSetBypassCSP() {
+	s := t.Serve()
+	s.Mux.HandleFunc("/", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Security-Policy", "script-src 'none'")
+		rw.Header().Set("Content-Type", "text/html")
+		_, _ = rw.Write([]byte("<html></html>"))
+	})
+
+	p := t.newPage("")
+	ran := func() bool {
+		t.E(p.AddScriptTag("", `window.ran = true`))
+		return p.MustEval(`() => window.ran === true`).Bool()
+	}
+
+	p.MustNavigate(s.URL())
+	t.False(ran())
+
+	p.MustSetBypassCSP(true).MustNavigate(s.URL())
+	t.True(ran())
+
+	t.mc.stubErr(1, proto.PageSetBypassCSP{})
+	t.Err(p.SetBypassCSP(false))
+}
+
+func (t T) PageSetBlockedURLs() {
+	s := t.Serve()
+	s.Route("/a.png", ".png", "")
+	s.Route("/b.js", ".js", "")
+	p := t.newPage(s.URL())
+
+	fetch := func(path string) string {
+		return p.MustEval(`u => fetch(u).then(() => 'ok', () => 'blocked')`, s.URL(path)).Str()
+	}
+
+	p.MustSetBlockedURLs(rod.ImagePatterns...)
+	t.Eq(fetch("/a.png"), "blocked")
+	t.Eq(fetch("/b.js"), "ok")
+
+	p.MustSetBlockedURLs()
+	t.Eq(fetch("/a.png"), "ok")
+
+	t.mc.stubErr(1, proto.NetworkEnable{})
+	t.Err(p.SetBlockedURLs(nil))
+}
+
+func (t T) PageThrottle() {
+	s := t.Serve()
+	s.Route("/a", ".txt", "ok")
+	p := t.newPage(s.URL())
+
+	fetch := func() string {
+		return p.MustEval(`u => fetch(u, { cache: 'no-store' }).then(() => 'ok', () => 'failed')`, s.URL("/a")).Str()
+	}
+
+	p.MustOffline(true)
+	t.Eq(fetch(), "failed")
+	p.MustOffline(false)
+	t.Eq(fetch(), "ok")
+
+	p.MustThrottleNetwork(proto.NetworkEmulateNetworkConditions{
+		Latency:            300,
+		DownloadThroughput: -1,
+		UploadThroughput:   -1,
+	})
+	start := time.Now()
+	t.Eq(fetch(), "ok")
+	t.Gte(time.Since(start), 300*time.Millisecond)
+
+	p.MustThrottleNetwork(devices.Fast3G).MustThrottleNetwork(devices.NoThrottling)
+
+	p.MustThrottleCPU(4).MustThrottleCPU(1)
+
+	t.mc.stubErr(1, proto.NetworkEnable{})
+	t.Err(p.ThrottleNetwork(devices.Slow3G))
+	t.mc.stubErr(1, proto.EmulationSetCPUThrottlingRate{})
+	t.Err(p.ThrottleCPU(2))
 }
 
 func (t T) SetExtraHeaders() {
@@ -67,7 +193,8 @@ func (t T) SetExtraHeaders() {
 
 	wg := sync.WaitGroup{}
 	var header http.Header
-	s.Mux.HandleFunc("/", func(rw http.ResponseWriter, r *http.Request) {
+	// only count the page, other requests such as the favicon don't matter
+	s.Mux.HandleFunc("/page", func(rw http.ResponseWriter, r *http.Request) {
 		header = r.Header
 		wg.Done()
 	})
@@ -76,7 +203,7 @@ func (t T) SetExtraHeaders() {
 	cleanup := p.MustSetExtraHeaders("a", "1", "b", "2")
 
 	wg.Add(1)
-	p.MustNavigate(s.URL())
+	p.MustNavigate(s.URL("/page"))
 	wg.Wait()
 
 	t.Eq(header.Get("a"), "1")
@@ -84,15 +211,71 @@ func (t T) SetExtraHeaders() {
 
 	cleanup()
 
-	// TODO: I don't know why it will fail randomly
-	if false {
-		wg.Add(1)
-		p.MustReload()
-		wg.Wait()
+	wg.Add(1)
+	p.MustReload()
+	wg.Wait()
 
-		t.Eq(header.Get("a"), "")
-		t.Eq(header.Get("b"), "")
+	t.Eq(header.Get("a"), "")
+	t.Eq(header.Get("b"), "")
+
+	_, err := p.SetExtraHeaders([]string{"host", "test.com"})
+	t.Is(err, &rod.ErrReservedHeader{})
+	t.Eq(err.Error(), "the header is reserved by the browser: host")
+}
+
+func (t T) PageSetGeolocation() {
+	p := t.newPage(t.blank()).MustSetGeolocation(10, 20, 1)
+	defer func() { t.E(proto.EmulationClearGeolocationOverride{}.Call(p)) }()
+
+	for _, args := range [][3]float64{{91, 0, 0}, {0, -181, 0}, {0, 0, -1}, {math.NaN(), 0, 0}} {
+		err := p.SetGeolocation(args[0], args[1], args[2])
+		t.Is(err, &rod.ErrInvalidGeolocation{})
 	}
+	t.Eq(p.SetGeolocation(91, 0, 0).Error(), "invalid geolocation: latitude 91, longitude 0, accuracy 0")
+
+	t.mc.stubErr(1, proto.BrowserGrantPermissions{})
+	t.Err(p.SetGeolocation(0, 0, 0))
+}
+
+func (t T) PageSetTimezoneAndLocale() {
+	p := t.newPage(t.blank()).MustSetTimezone("America/New_York").MustSetLocale("fr_FR")
+
+	opts := p.MustEval(`Intl.DateTimeFormat().resolvedOptions()`)
+	t.Eq(opts.Get("timeZone").Str(), "America/New_York")
+	t.Eq(opts.Get("locale").Str(), "fr-FR")
+
+	t.Err(p.SetTimezone("Invalid/Zone"))
+
+	p.MustSetTimezone("").MustSetLocale("")
+}
+
+func (t T) PageClipboard() {
+	s := t.Serve().Route("/", ".html", `<html></html>`)
+	p := t.newPage(s.URL()).MustWaitLoad()
+
+	t.Eq(p.MustWriteClipboard("hello 你好").MustReadClipboard(), "hello 你好")
+
+	t.mc.stub(1, proto.RuntimeCallFunctionOn{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(proto.RuntimeCallFunctionOnResult{Result: &proto.RuntimeRemoteObject{
+			Type:  proto.RuntimeRemoteObjectTypeString,
+			Value: gson.New("http://test.com"),
+		}}), nil
+	})
+	err := p.WriteClipboard("a")
+	t.Is(err, &rod.ErrNotSecureContext{})
+	t.Eq(err.Error(), "the api is only available in secure contexts, such as https or localhost: http://test.com")
+
+	t.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	t.Err(p.ReadClipboard())
+
+	t.mc.stubErr(1, proto.BrowserGrantPermissions{})
+	t.Err(p.ReadClipboard())
+
+	t.mc.stubErr(2, proto.RuntimeCallFunctionOn{})
+	t.Err(p.ReadClipboard())
+
+	t.mc.stubErr(1, proto.PageBringToFront{})
+	t.Err(p.WriteClipboard("a"))
 }
 
 func (t T) SetUserAgent() {
@@ -137,6 +320,11 @@ func (t T) LoadState() {
 
 func (t T) PageContext() {
 	t.page.Timeout(time.Hour).CancelTimeout().MustEval(`1`)
+
+	p := t.page.Timeout(time.Hour)
+	ctx := p.GetContext()
+	t.Eq(t.page.GetContext(), p.CancelTimeout().GetContext())
+	t.Eq(ctx.Err(), context.Canceled)
 }
 
 func (t T) Window() {
@@ -208,6 +396,42 @@ func (t T) EmulateDevice() {
 	})
 }
 
+func (t T) PageCloseTwice() {
+	page := t.browser.MustPage(t.blank())
+	page.MustClose()
+	t.E(page.Close())
+}
+
+func (t T) PageCloseOutOfRod() {
+	page := t.browser.MustPage(t.blank())
+
+	e := &proto.TargetTargetDestroyed{}
+	wait := t.browser.WaitEventBy(e, func() bool { return e.TargetID == page.TargetID })
+	_, err := proto.TargetCloseTarget{TargetID: page.TargetID}.Call(t.browser)
+	t.E(err)
+	wait()
+
+	t.E(page.Close())
+}
+
+func (t T) PageCloseFromSession() {
+	page := t.browser.MustPage(t.blank())
+
+	e := &proto.TargetTargetDestroyed{}
+	wait := t.browser.WaitEventBy(e, func() bool { return e.TargetID == page.TargetID })
+	t.E(t.browser.PageFromSession(page.SessionID).Close())
+	wait()
+}
+
+func (t T) PageCallAfterClose() {
+	page := t.browser.MustPage(t.blank())
+	page.MustClose()
+	err := proto.PageReload{}.Call(page)
+	t.Is(err, &rod.ErrTargetClosed{})
+	t.Is(err, cdp.ErrSessionNotFound)
+	t.Eq(err.Error(), "the target is closed: "+string(page.TargetID))
+}
+
 func (t T) PageCloseErr() {
 	page := t.newPage(t.blank())
 	t.Panic(func() {
@@ -216,6 +440,59 @@ func (t T) PageCloseErr() {
 	})
 }
 
+func (t T) PageSaveAndRestoreState() {
+	s := t.Serve().Route("/", ".html", `<html></html>`)
+
+	p := t.newPage(s.URL()).MustWaitLoad()
+	p.MustEval(`() => {
+		document.cookie = "a=1"
+		localStorage.setItem("b", "2")
+		sessionStorage.setItem("c", "3")
+	}`)
+
+	data := utils.MustToJSONBytes(p.MustSaveState())
+	var state rod.SessionState
+	t.E(json.Unmarshal(data, &state))
+	t.Eq(state.Origin, s.URL())
+	t.Eq(state.LocalStorage, map[string]string{"b": "2"})
+	t.Eq(state.SessionStorage, map[string]string{"c": "3"})
+
+	b := t.browser.MustIncognito()
+	defer b.MustClose()
+
+	p2 := b.MustPage(state.Origin).MustWaitLoad().MustRestoreState(&state)
+	t.Eq(p2.MustEval(`document.cookie`).Str(), "a=1")
+	t.Eq(p2.MustEval(`localStorage.getItem("b")`).Str(), "2")
+	t.Eq(p2.MustEval(`sessionStorage.getItem("c")`).Str(), "3")
+
+	t.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	t.Err(p.SaveState())
+	t.mc.stubErr(1, proto.NetworkGetCookies{})
+	t.Err(p.SaveState())
+	t.mc.stubErr(1, proto.DOMStorageGetDOMStorageItems{})
+	t.Err(p.SaveState())
+	t.mc.stubErr(2, proto.DOMStorageGetDOMStorageItems{})
+	t.Err(p.SaveState())
+
+	t.mc.stubErr(1, proto.NetworkSetCookies{})
+	t.Err(p2.RestoreState(&state))
+	t.mc.stubErr(1, proto.DOMStorageSetDOMStorageItem{})
+	t.Err(p2.RestoreState(&state))
+	t.mc.stubErr(2, proto.DOMStorageSetDOMStorageItem{})
+	t.Err(p2.RestoreState(&state))
+}
+
+func (t T) PageSetDocumentContent() {
+	p := t.page.MustNavigate(t.blank())
+
+	p.MustSetDocumentContent(`<html><body><button>ok</button></body></html>`)
+	t.Eq(p.MustElement("button").MustText(), "ok")
+	t.Has(p.MustHTML(), "<button>ok</button>")
+
+	t.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	t.Err(p.HTML())
+}
+
 func (t T) PageAddScriptTag() {
 	p := t.page.MustNavigate(t.blank()).MustWaitLoad()
 
@@ -256,6 +533,11 @@ func (t T) PageWaitOpen() {
 	defer newPage.MustClose()
 
 	t.Eq("new page", newPage.MustEval("window.a").String())
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err := page.Context(ctx).WaitOpen()()
+	t.Is(err, context.Canceled)
 }
 
 func (t T) PageWaitPauseOpen() {
@@ -312,6 +594,192 @@ func (t T) PageWaitNavigation() {
 	wait := t.page.MustWaitNavigation()
 	t.page.MustNavigate(s.URL())
 	wait()
+
+	p := t.newPage("")
+	t.E(proto.PageSetLifecycleEventsEnabled{Enabled: true}.Call(p))
+	wait = p.MustWaitNavigation()
+	p.MustNavigate(s.URL())
+	wait()
+	state := &proto.PageSetLifecycleEventsEnabled{}
+	t.True(p.LoadState(state))
+	t.True(state.Enabled)
+}
+
+func (t T) PageWaitNavigationIgnoreSubFrames() {
+	s := t.Serve()
+	s.Route("/", ".html", `<html><iframe></iframe></html>`)
+	s.Route("/sub", ".html", `<html>sub</html>`)
+
+	p := t.newPage(s.URL()).MustWaitLoad()
+
+	done := make(chan struct{})
+	wait := p.WaitNavigation(proto.PageLifecycleEventNameLoad)
+	go func() {
+		wait()
+		close(done)
+	}()
+
+	waitSub := p.EachEvent(func(e *proto.PageLifecycleEvent) bool {
+		return e.FrameID != p.FrameID && e.Name == proto.PageLifecycleEventNameLoad
+	})
+	p.MustEval(`u => document.querySelector("iframe").src = u`, s.URL("/sub"))
+	waitSub()
+
+	utils.Sleep(0.3)
+	select {
+	case <-done:
+		t.Fail()
+	default:
+	}
+
+	p.MustNavigate(s.URL())
+	<-done
+}
+
+func (t T) PageWaitRequest() {
+	s := t.Serve()
+	s.Route("/", ".html", `<html></html>`)
+	s.Route("/a", ".txt", "ok")
+
+	p := t.newPage(s.URL()).MustWaitLoad()
+
+	wait := p.MustWaitRequest(`/a$`)
+	p.MustEval(`(u) => fetch(u, { method: 'POST', body: 'data' })`, s.URL("/a"))
+	req := wait()
+
+	t.Eq(s.URL("/a"), req.URL)
+	t.Eq(http.MethodPost, req.Method)
+	t.Eq("data", req.PostData)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err := p.Context(ctx).WaitRequest(`/a$`)()
+	t.Is(err, context.Canceled)
+
+	_, err = p.WaitRequest(`(`)()
+	t.Err(err)
+}
+
+func (t T) PageWaitResponse() {
+	s := t.Serve()
+	s.Route("/", ".html", `<html></html>`)
+	s.Route("/a", ".txt", "ok")
+	s.Route("/b", ".bin", []byte{0, 1, 2})
+
+	p := t.newPage(s.URL()).MustWaitLoad()
+	fetch := func(u string) { p.MustEval(`(u) => { fetch(u) }`, u) }
+
+	wait := p.MustWaitResponse(`/a$`)
+	fetch(s.URL("/a"))
+	res, body := wait()
+	t.Eq(200, res.Status)
+	t.Eq("ok", string(body))
+
+	wait = p.MustWaitResponse(`/b$`)
+	fetch(s.URL("/b"))
+	_, body = wait()
+	t.Eq([]byte{0, 1, 2}, body)
+
+	waitErr := p.WaitResponse(`/c$`)
+	fetch("http://127.0.0.1:1/c")
+	_, _, err := waitErr()
+	t.Is(err, &rod.ErrLoadingFailed{})
+	t.Has(err.Error(), "failed to load the resource")
+
+	waitErr = p.WaitResponse(`/a$`)
+	t.mc.stubErr(1, proto.NetworkGetResponseBody{})
+	fetch(s.URL("/a"))
+	_, _, err = waitErr()
+	t.Err(err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, _, err = p.Context(ctx).WaitResponse(`/a$`)()
+	t.Is(err, context.Canceled)
+
+	_, _, err = p.WaitResponse(`(`)()
+	t.Err(err)
+}
+
+func (t T) PageWaitResponseWithoutResponse() {
+	events := make(chan *cdp.Event)
+	c := &MockClient{
+		connect: func() error { return nil },
+		call: func(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+			switch method {
+			case "Target.getTargetInfo":
+				return []byte(`{"targetInfo":{"type":"page"}}`), nil
+			case "Runtime.evaluate":
+				return []byte(`{"result":{"objectId":"{\"injectedScriptId\":1,\"id\":1}"}}`), nil
+			}
+			return []byte(`{}`), nil
+		},
+		event: events,
+	}
+	p := rod.New().Client(c).MustConnect().MustPageFromTargetID("id")
+
+	wait := p.WaitResponse(`/a$`)
+	go func() {
+		events <- &cdp.Event{Method: "Network.requestWillBeSent", Params: []byte(`{"requestId":"1","request":{"url":"/a"}}`)}
+		events <- &cdp.Event{Method: "Network.loadingFinished", Params: []byte(`{"requestId":"1"}`)}
+	}()
+	_, _, err := wait()
+	t.Is(err, &rod.ErrNoResponse{})
+	t.Eq(err.Error(), "no response received for the request: 1")
+	close(events)
+}
+
+func (t T) PageWaitDownload() {
+	s := t.Serve()
+	s.Route("/d", ".bin", "test content")
+	s.Mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		_, _ = w.Write([]byte("part"))
+		panic(http.ErrAbortHandler)
+	})
+	s.Route("/", ".html", `<html><a id="d" href="/d" download>d</a><a id="b" href="/broken" download>b</a></html>`)
+
+	dir, err := filepath.Abs(filepath.Join("tmp", "downloads", t.Srand(8)))
+	t.E(err)
+
+	p := t.newPage(s.URL()).MustWaitLoad()
+
+	wait := p.MustWaitDownload(dir)
+	p.MustElement("#d").MustClick()
+	data, err := ioutil.ReadFile(wait())
+	t.E(err)
+	t.Eq("test content", string(data))
+
+	waitErr := p.WaitDownload(dir)
+	p.MustElement("#b").MustClick()
+	_, err = waitErr()
+	t.Is(err, &rod.ErrDownloadCanceled{})
+	t.Has(err.Error(), "download canceled")
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	_, err = p.Context(ctx).WaitDownload(dir)()
+	t.Is(err, context.Canceled)
+
+	t.mc.stubErr(1, proto.BrowserSetDownloadBehavior{})
+	_, err = p.WaitDownload(dir)()
+	t.Err(err)
+
+	// restore the previous behavior
+	t.E(proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorDeny,
+		BrowserContextID: t.browser.BrowserContextID,
+	}.Call(t.browser))
+	wait = p.MustWaitDownload(dir)
+	p.MustElement("#d").MustClick()
+	wait()
+	prev := &proto.BrowserSetDownloadBehavior{}
+	t.True(t.browser.LoadState("", prev))
+	t.Eq(prev.Behavior, proto.BrowserSetDownloadBehaviorBehaviorDeny)
+	t.E(proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorDefault,
+		BrowserContextID: t.browser.BrowserContextID,
+	}.Call(t.browser))
 }
 
 func (t T) PageWaitRequestIdle() {
@@ -382,6 +850,24 @@ func (t T) PageWaitIdle() {
 	t.True(p.MustHas("[a=ok]"))
 }
 
+func (t T) PageWaitSettled() {
+	s := t.Serve()
+	s.Mux.HandleFunc("/slow", func(rw http.ResponseWriter, r *http.Request) {
+		utils.Sleep(0.3)
+	})
+	p := t.newPage(s.URL())
+
+	p.MustEval(`u => setTimeout(() => fetch(u).then(() => document.body.setAttribute('a', 'ok')), 100)`, s.URL("/slow"))
+	p.MustWaitSettled()
+	t.True(p.MustHas("[a=ok]"))
+
+	t.E(p.WaitSettled(0, 0))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	t.Is(p.Context(ctx).WaitSettled(time.Millisecond, 0), context.Canceled)
+}
+
 func (t T) PageEventSession() {
 	s := t.Serve()
 	p := t.newPage(s.URL())
@@ -400,6 +886,30 @@ func (t T) PageWaitEvent() {
 	wait()
 }
 
+func (t T) PageWaitEventBy() {
+	s := t.Serve()
+	s.Route("/a", "", "a")
+	s.Route("/b", "", "b")
+	p := t.newPage(s.URL())
+
+	e := &proto.NetworkRequestWillBeSent{}
+	wait := p.WaitEventBy(e, func() bool { return strings.HasSuffix(e.Request.URL, "/b") })
+	p.MustEval(`u => fetch(u)`, s.URL("/a"))
+	p.MustEval(`u => fetch(u)`, s.URL("/b"))
+	wait()
+	t.Eq(e.Request.URL, s.URL("/b"))
+
+	n := 0
+	wait = t.browser.WaitEventBy(&proto.PageFrameNavigated{}, func() bool {
+		n++
+		return n == 2
+	})
+	p.MustNavigate(s.URL("/a"))
+	p.MustNavigate(s.URL("/b"))
+	wait()
+	t.Eq(n, 2)
+}
+
 func (t T) PageEvent() {
 	p := t.browser.MustPage("")
 	ctx := t.Context()
@@ -549,6 +1059,28 @@ func (t T) PageScreenshot() {
 
 	p.MustScreenshot("")
 
+	data, err = p.Screenshot(false, nil)
+	t.E(err)
+	_, err = png.Decode(bytes.NewBuffer(data))
+	t.E(err)
+
+	data, err = p.Screenshot(false, &proto.PageCaptureScreenshot{
+		Format:  proto.PageCaptureScreenshotFormatJpeg,
+		Quality: 50,
+		Clip: &proto.PageViewport{
+			X:      10,
+			Y:      10,
+			Width:  100,
+			Height: 50,
+			Scale:  1,
+		},
+	})
+	t.E(err)
+	img, err = jpeg.Decode(bytes.NewBuffer(data))
+	t.E(err)
+	t.Eq(100, img.Bounds().Dx())
+	t.Eq(50, img.Bounds().Dy())
+
 	t.Panic(func() {
 		t.mc.stubErr(1, proto.PageCaptureScreenshot{})
 		p.MustScreenshot()
@@ -593,6 +1125,60 @@ func (t T) ScreenshotFullPageInit() {
 	p.MustScreenshotFullPage()
 }
 
+func (t T) PageInputType() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/input.html"))
+
+	el := p.MustElement("input")
+	el.MustFocus()
+	p.Keyboard.MustType("Ab 中", 0)
+
+	t.Eq("Ab 中", el.MustText())
+
+	t.mc.stubErr(1, proto.InputDispatchKeyEvent{})
+	t.Err(p.Keyboard.Type("a", 0))
+}
+
+func (t T) PageInputTypeDelay() {
+	b, cancel := t.browser.WithCancel()
+	defer cancel()
+	p := b.MustPage(t.srcFile("fixtures/input.html"))
+	defer func() {
+		// the context of p will be canceled
+		_, err := proto.TargetCloseTarget{TargetID: p.TargetID}.Call(t.browser)
+		t.E(err)
+	}()
+
+	el := p.MustElement("input")
+	el.MustFocus()
+
+	start := time.Now()
+	p.Keyboard.MustType("abc", 100*time.Millisecond)
+	t.Gte(time.Since(start), 200*time.Millisecond)
+	t.Eq("abc", el.MustText())
+
+	// the context is canceled in the middle of the typing
+	go func() {
+		utils.Sleep(0.15)
+		cancel()
+	}()
+	err := p.Keyboard.Type("def", 100*time.Millisecond)
+	t.Eq(err, context.Canceled)
+}
+
+func (t T) PageInputSlowMotion() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/input.html"))
+	el := p.MustElement("input")
+	el.MustFocus()
+
+	t.browser.SlowMotion(50 * time.Millisecond)
+	defer func() { t.browser.SlowMotion(defaults.Slow) }()
+
+	// each printable key dispatches the keyDown, char, and keyUp events
+	start := time.Now()
+	p.Keyboard.MustType("abcde", 0)
+	t.Gte(time.Since(start), 5*3*50*time.Millisecond)
+}
+
 func (t T) PageInput() {
 	p := t.page.MustNavigate(t.srcFile("fixtures/input.html"))
 
@@ -646,6 +1232,10 @@ func (t T) PageConsoleLog() {
 	wait()
 	t.Eq("test", p.MustObjectToJSON(e.Args[1]).Get("b.0").String())
 	t.Eq(`1 map[b:[test]]`, p.MustObjectsToJSON(e.Args).Join(" "))
+
+	t.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	_, err := p.ObjectsToJSON(e.Args)
+	t.Err(err)
 }
 
 func (t T) Fonts() {
@@ -658,6 +1248,12 @@ func (t T) PagePDF() {
 	p := t.page.MustNavigate(t.srcFile("fixtures/click.html"))
 	p.MustPDF("")
 
+	r, err := p.PDF(nil)
+	t.E(err)
+	bin, err := ioutil.ReadAll(r)
+	t.E(err)
+	t.Has(string(bin[:5]), "%PDF-")
+
 	t.Panic(func() {
 		t.mc.stubErr(1, proto.PagePrintToPDF{})
 		p.MustPDF()
@@ -693,6 +1289,26 @@ func (t T) PageNavigateErr() {
 	})
 }
 
+func (t T) PageNavigateWithOptions() {
+	s := t.Serve()
+	referer := ""
+	s.Mux.HandleFunc("/t", func(w http.ResponseWriter, r *http.Request) {
+		referer = r.Header.Get("Referer")
+	})
+
+	wait := t.page.WaitNavigation(proto.PageLifecycleEventNameLoad)
+	t.page.MustNavigateWithOptions(&proto.PageNavigate{
+		URL:            s.URL("/t"),
+		Referrer:       "http://test.com/",
+		TransitionType: proto.PageTransitionTypeLink,
+	})
+	wait()
+	t.Eq(referer, "http://test.com/")
+
+	t.page.MustNavigateWithOptions(nil)
+	t.Eq(t.page.MustInfo().URL, "about:blank")
+}
+
 func (t T) PageWaitLoadErr() {
 	t.Panic(func() {
 		t.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
@@ -742,3 +1358,102 @@ func (t T) PageUseNonExistSession(got.Skip) {
 	err := proto.PageClose{}.Call(p)
 	t.Is(err, context.DeadlineExceeded)
 }
+
+func (t T) PageGetResourceTree() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/click-iframe.html"))
+	p.MustElement("iframe").MustFrame().MustElement("button")
+
+	tree := p.MustGetResourceTree()
+	t.Eq(tree.Frame.ID, p.FrameID)
+	t.Len(tree.ChildFrames, 1)
+
+	child := tree.ChildFrames[0].Frame
+	t.Has(string(p.MustGetResource(child.URL)), "button")
+
+	_, err := p.GetResource("http://not-exists.com/a.png")
+	t.Is(err, &rod.ErrResourceNotFound{})
+	t.Eq(err.Error(), "the resource isn't in the resource tree of the page: http://not-exists.com/a.png")
+}
+
+func (t T) PageMetrics() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/click.html"))
+
+	before := p.MustMetrics()
+	t.Gt(before["Nodes"], 0.0)
+	t.Gt(before["JSHeapUsedSize"], 0.0)
+
+	p.MustEval(`() => { for (let i = 0; i < 100; i++) document.body.appendChild(document.createElement('p')) }`)
+	t.Gt(p.MustMetrics()["Nodes"], before["Nodes"])
+
+	t.mc.stubErr(1, proto.PerformanceGetMetrics{})
+	t.Err(p.Metrics())
+}
+
+func (t T) PageTracing() {
+	p := t.newPage("")
+
+	p.MustStartTracing("devtools.timeline").MustNavigate(t.srcFile("fixtures/click.html"))
+	var trace struct {
+		TraceEvents []map[string]interface{} `json:"traceEvents"`
+	}
+	t.E(json.Unmarshal(p.MustStopTracing(), &trace))
+	t.Gt(len(trace.TraceEvents), 0)
+
+	t.E(proto.TracingStart{TransferMode: proto.TracingStartTransferModeReportEvents}.Call(p))
+	p.MustReload()
+	trace.TraceEvents = nil
+	t.E(json.Unmarshal(p.MustStopTracing(), &trace))
+	t.Gt(len(trace.TraceEvents), 0)
+
+	t.mc.stubErr(1, proto.TracingEnd{})
+	t.Err(p.StopTracing())
+
+	t.E(p.StartTracing(nil))
+	defer func() { t.E(proto.TracingEnd{}.Call(p)) }()
+	pCancel, cancel := p.WithCancel()
+	t.mc.stub(1, proto.TracingEnd{}, func(send StubSend) (gson.JSON, error) {
+		cancel()
+		return gson.New(nil), nil
+	})
+	t.Err(pCancel.StopTracing())
+}
+
+func (t T) PageCaptureSnapshot() {
+	f := filepath.Join("tmp", "mhtml", t.Srand(16)+".mhtml")
+	p := t.page.MustNavigate(t.srcFile("fixtures/click.html"))
+
+	mhtml := p.MustCaptureSnapshot(f)
+	t.Has(mhtml, "MIME-Version: 1.0")
+	t.Has(mhtml, "click me")
+	t.Nil(os.Stat(f))
+
+	p.MustCaptureSnapshot("")
+
+	t.mc.stubErr(1, proto.PageCaptureSnapshot{})
+	t.Err(p.CaptureSnapshot())
+}
+
+func (t T) PageWaitFileChooser() {
+	p := t.newPage(t.blank())
+	p.MustSetDocumentContent(`<input type="file" hidden>` +
+		`<button onclick="document.querySelector('input').click()">upload</button>`)
+
+	wait := p.MustWaitFileChooser()
+	p.MustElement("button").MustClick()
+	wait().MustSetFiles(slash("fixtures/click.html"))
+	t.Eq(p.MustEval(`() => document.querySelector('input').files[0].name`).Str(), "click.html")
+
+	waitErr := p.WaitFileChooser()
+	p.MustElement("button").MustClick()
+	t.mc.stubErr(1, proto.DOMResolveNode{})
+	t.Err(waitErr())
+
+	t.mc.stubErr(1, proto.PageSetInterceptFileChooserDialog{})
+	t.Err(p.WaitFileChooser()())
+
+	pCancel, cancel := p.WithCancel()
+	waitErr = pCancel.WaitFileChooser()
+	cancel()
+	_, err := waitErr()
+	t.Is(err, context.Canceled)
+}
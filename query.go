@@ -102,7 +102,12 @@ func (ps Pages) FindByURL(jsRegex string) (*Page, error) {
 	return nil, nil
 }
 
-// Has an element that matches the css selector
+// Has an element that matches the css selector. It doesn't retry, it returns false without error
+// if nothing matches, such as to click the cookie banner only if it's there:
+//
+//     if has, el, _ := page.Has("#cookie-banner button"); has {
+//         el.MustClick()
+//     }
 func (p *Page) Has(selector string) (bool, *Element, error) {
 	el, err := p.Sleeper(nil).Element(selector)
 	if errors.Is(err, &ErrElementNotFound{}) {
@@ -130,11 +135,38 @@ func (p *Page) HasR(selector, jsRegex string) (bool, *Element, error) {
 }
 
 // Element retries until an element in the page that matches the CSS selector, then returns
-// the matched element.
+// the matched element. Use Page.Timeout to limit the retry, and Element.WaitVisible to wait
+// until the element is rendered, such as page.Timeout(time.Minute).MustElement("a").MustWaitVisible()
 func (p *Page) Element(selector string) (*Element, error) {
 	return p.ElementByJS(EvalHelper(js.Element, selector))
 }
 
+// WaitElementThen waits for the element that matches the CSS selector, then runs the action on it.
+// If the element is detached from the document by the page before or during the action, it will
+// query the element again and retry the action once, such as:
+//
+//     page.WaitElementThen("button", func(el *rod.Element) error { return el.Click("left") })
+func (p *Page) WaitElementThen(selector string, action func(*Element) error) error {
+	for retried := false; ; retried = true {
+		el, err := p.Element(selector)
+		if err != nil {
+			return err
+		}
+
+		err = action(el)
+		if !retried && isDetachedErr(err) {
+			continue
+		}
+		return err
+	}
+}
+
+func isDetachedErr(err error) bool {
+	return errors.Is(err, &ErrObjectNotFound{}) ||
+		errors.Is(err, cdp.ErrObjNotFound) ||
+		errors.Is(err, cdp.ErrNodeDetached)
+}
+
 // ElementR retries until an element in the page that matches the css selector and it's text matches the jsRegex,
 // then returns the matched element.
 func (p *Page) ElementR(selector, jsRegex string) (*Element, error) {
@@ -147,7 +179,8 @@ func (p *Page) ElementX(xPath string) (*Element, error) {
 	return p.ElementByJS(EvalHelper(js.ElementX, xPath))
 }
 
-// ElementByJS returns the element from the return value of the js function.
+// ElementByJS returns the element from the return value of the js function, such as `() => document.activeElement`.
+// If the return value isn't an element, such as a text node, an *ErrExpectElement will be returned.
 // If sleeper is nil, no retry will be performed.
 // By default, it will retry until the js function doesn't return null.
 // To customize the retry logic, check the examples of Page.Sleeper.
@@ -184,14 +217,34 @@ func (p *Page) ElementByJS(opts *EvalOptions) (*Element, error) {
 		return nil, err
 	}
 
-	if res.Subtype != proto.RuntimeRemoteObjectSubtypeNode {
+	yes, err := p.isElement(res)
+	if err != nil {
+		return nil, err
+	}
+	if !yes {
 		return nil, &ErrExpectElement{res}
 	}
 
 	return p.ElementFromObject(res), nil
 }
 
-// Elements returns all elements that match the css selector
+// isElement returns false if the obj isn't an element node, such as a text node, a doctype, or the document
+func (p *Page) isElement(obj *proto.RuntimeRemoteObject) (bool, error) {
+	if obj.Subtype != proto.RuntimeRemoteObjectSubtypeNode {
+		return false, nil
+	}
+
+	node, err := proto.DOMDescribeNode{ObjectID: obj.ObjectID}.Call(p)
+	if err != nil {
+		return false, err
+	}
+
+	// the nodeType of an element is 1: https://developer.mozilla.org/en-US/docs/Web/API/Node/nodeType
+	return node.Node.NodeType == 1, nil
+}
+
+// Elements returns all elements that match the css selector.
+// If nothing matches, an empty list will be returned.
 func (p *Page) Elements(selector string) (Elements, error) {
 	return p.ElementsByJS(EvalHelper(js.Elements, selector))
 }
@@ -212,7 +265,7 @@ func (p *Page) ElementsByJS(opts *EvalOptions) (Elements, error) {
 		return nil, &ErrExpectElements{res}
 	}
 
-	defer func() { err = p.Release(res) }()
+	defer func() { _ = p.Release(res) }()
 
 	list, err := proto.RuntimeGetProperties{
 		ObjectID:      res.ObjectID,
@@ -229,19 +282,25 @@ func (p *Page) ElementsByJS(opts *EvalOptions) (Elements, error) {
 		}
 		val := obj.Value
 
-		if val.Subtype != proto.RuntimeRemoteObjectSubtypeNode {
+		yes, err := p.isElement(val)
+		if err != nil {
+			return nil, err
+		}
+		if !yes {
 			return nil, &ErrExpectElements{val}
 		}
 
 		elemList = append(elemList, p.ElementFromObject(val))
 	}
 
-	return elemList, err
+	return elemList, nil
 }
 
 // Search for each given query in the DOM tree until the result count is not zero, before that it will keep retrying.
 // The query can be plain text or css selector or xpath.
 // It will search nested iframes and shadow doms too.
+// The from and to are the range of the result, the to will be capped to the result count,
+// if the from is out of the range, an empty list will be returned.
 func (p *Page) Search(from, to int, queries ...string) (Elements, error) {
 	sleeper := p.sleeper()
 	if sleeper == nil {
@@ -269,10 +328,19 @@ func (p *Page) Search(from, to int, queries ...string) (Elements, error) {
 			return false, nil
 		}
 
+		end := to
+		if end > search.ResultCount {
+			end = search.ResultCount
+		}
+		if from >= end {
+			list = Elements{}
+			return true, nil
+		}
+
 		result, err := proto.DOMGetSearchResults{
 			SearchID:  search.SearchID,
-			FromIndex: int(from),
-			ToIndex:   int(to),
+			FromIndex: from,
+			ToIndex:   end,
 		}.Call(p)
 		if err != nil {
 			// when the page is still loading the search result is not ready
@@ -282,6 +350,8 @@ func (p *Page) Search(from, to int, queries ...string) (Elements, error) {
 			return true, err
 		}
 
+		list = Elements{}
+
 		for _, id := range result.NodeIds {
 			// TODO: some times the node id can be zero, feels like a bug of devtools server
 			if id == 0 {
@@ -463,6 +533,11 @@ func (el *Element) Previous() (*Element, error) {
 	return el.ElementByJS(Eval(`this.previousElementSibling`))
 }
 
+// Children returns the child elements in the DOM tree
+func (el *Element) Children() (Elements, error) {
+	return el.Elements(":scope > *")
+}
+
 // Elements returns all elements that match the css selector
 func (el *Element) Elements(selector string) (Elements, error) {
 	return el.ElementsByJS(EvalHelper(js.Elements, selector))
@@ -17,6 +17,44 @@ func (t T) PageElements() {
 	list := t.page.MustElements("input")
 	t.Eq("input", list.First().MustDescribe().LocalName)
 	t.Eq("submit", list.Last().MustText())
+
+	t.True(t.page.MustElements("not-exists").Empty())
+}
+
+func (t T) PageWaitElementThen() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/click.html"))
+
+	count := 0
+	p.MustWaitElementThen("button", func(el *rod.Element) error {
+		count++
+		if count == 1 {
+			return cdp.ErrNodeDetached
+		}
+		return el.Click(proto.InputMouseButtonLeft)
+	})
+	t.Eq(count, 2)
+	t.True(p.MustHas("[a=ok]"))
+
+	// only retry once
+	count = 0
+	err := p.WaitElementThen("button", func(*rod.Element) error {
+		count++
+		return &rod.ErrObjectNotFound{}
+	})
+	t.Is(err, &rod.ErrObjectNotFound{})
+	t.Eq(count, 2)
+
+	// other errors won't be retried
+	count = 0
+	err = p.WaitElementThen("button", func(*rod.Element) error {
+		count++
+		return errors.New("err")
+	})
+	t.Eq(err.Error(), "err")
+	t.Eq(count, 1)
+
+	t.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	t.Err(p.WaitElementThen("button", func(*rod.Element) error { return nil }))
 }
 
 func (t T) Pages() {
@@ -71,6 +109,9 @@ func (t T) Search() {
 	t.True(errors.Is(err, &rod.ErrElementNotFound{}))
 	t.Eq(err.Error(), "cannot find element")
 
+	_, err = p.Sleeper(nil).Element("not-exists")
+	t.Is(err, &rod.ErrElementNotFound{})
+
 	// when search result is not ready
 	{
 		t.mc.stub(1, proto.DOMGetSearchResults{}, func(send StubSend) (gson.JSON, error) {
@@ -103,6 +144,24 @@ func (t T) Search() {
 	})
 }
 
+func (t T) SearchRange() {
+	p := t.page.MustNavigate(t.srcFile("fixtures/selector.html"))
+
+	list, err := p.Search(0, 100, "body > button")
+	t.E(err)
+	t.Len(list, 2)
+	t.Eq(list.Last().MustText(), "04")
+
+	list, err = p.Search(1, 100, "body > button")
+	t.E(err)
+	t.Len(list, 1)
+	t.Eq(list.First().MustText(), "04")
+
+	list, err = p.Search(5, 10, "body > button")
+	t.E(err)
+	t.Len(list, 0)
+}
+
 func (t T) SearchIframes() {
 	p := t.page.MustNavigate(t.srcFile("fixtures/click-iframes.html"))
 	el := p.MustSearch("button[onclick]")
@@ -213,6 +272,11 @@ func (t T) ElementSiblings() {
 
 	t.Eq(a.MustText(), "01")
 	t.Eq(b.MustText(), "04")
+
+	list := el.MustChildren()
+	t.Len(list, 2)
+	t.Eq(list.Last().MustText(), "03")
+	t.Len(list.First().MustChildren(), 0)
 }
 
 func (t T) ElementFromElementX() {
@@ -247,6 +311,31 @@ func (t T) PageElementByJS() {
 	_, err := p.ElementByJS(rod.Eval(`1`))
 	t.Is(err, &rod.ErrExpectElement{})
 	t.Eq(err.Error(), "expect js to return an element, but got: {\"type\":\"number\",\"value\":1,\"description\":\"1\"}")
+
+	p.MustElement("button").MustFocus()
+	t.Eq(p.MustElementByJS(`() => document.activeElement`).MustText(), "click me")
+
+	_, err = p.ElementByJS(rod.Eval(`() => document.createTextNode('a')`))
+	t.Is(err, &rod.ErrExpectElement{})
+
+	_, err = p.ElementsByJS(rod.Eval(`() => [document.createComment('a')]`))
+	t.Is(err, &rod.ErrExpectElements{})
+
+	_, err = p.ElementByJS(rod.Eval(`() => document.body.firstChild`)) // the text node of the whitespace
+	t.Is(err, &rod.ErrExpectElement{})
+
+	for _, js := range []string{`document.doctype`, `document`, `document.createDocumentFragment()`} {
+		_, err = p.ElementByJS(rod.Eval(js))
+		t.Is(err, &rod.ErrExpectElement{})
+	}
+
+	t.mc.stubErr(1, proto.DOMDescribeNode{})
+	_, err = p.ElementByJS(rod.Eval(`document.body`))
+	t.Err(err)
+
+	t.mc.stubErr(1, proto.DOMDescribeNode{})
+	_, err = p.ElementsByJS(rod.Eval(`[document.body]`))
+	t.Err(err)
 }
 
 func (t T) PageElementsByJS() {
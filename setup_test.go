@@ -193,6 +193,7 @@ func (t T) checkLeaking(checkGoroutine bool) {
 type Call func(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error)
 
 var _ rod.CDPClient = &MockClient{}
+var _ rod.CDPClient = &cdp.Player{}
 
 type MockClient struct {
 	sync.RWMutex
@@ -23,6 +23,8 @@ import (
 )
 
 // CDPClient is usually used to make rod side-effect free. Such as proxy all IO of rod.
+// To test the code that depends on rod without a browser, record a real session via cdp.NewRecorder,
+// then replay it via cdp.NewPlayer, it's a CDPClient.
 type CDPClient interface {
 	Connect(ctx context.Context) error
 	Event() <-chan *cdp.Event
@@ -87,7 +89,6 @@ type PagePool chan *Page
 
 // NewPagePool instance
 func NewPagePool(limit int) PagePool {
-	// we create a pool that will hold at most 3 pages
 	pp := make(chan *Page, limit)
 	for i := 0; i < limit; i++ {
 		pp <- nil
@@ -95,7 +96,9 @@ func NewPagePool(limit int) PagePool {
 	return pp
 }
 
-// Get a page from the pool. Use the PagePool.Put to make it reusable later.
+// Get a page from the pool, it blocks until a page is available. If there's no idle page, create will be used to
+// create a new one. Use the PagePool.Put to make it reusable later, such as navigate it to "about:blank" before
+// putting it back to reset it.
 func (pp PagePool) Get(create func() *Page) *Page {
 	p := <-pp
 	if p == nil {
@@ -214,6 +217,7 @@ type saveFileType int
 const (
 	saveFileTypeScreenshot saveFileType = iota
 	saveFileTypePDF
+	saveFileTypeMHTML
 )
 
 func saveFile(fileType saveFileType, bin []byte, toFile []string) error {
@@ -227,6 +231,8 @@ func saveFile(fileType saveFileType, bin []byte, toFile []string) error {
 			toFile = []string{"tmp", "screenshots", stamp + ".png"}
 		case saveFileTypePDF:
 			toFile = []string{"tmp", "pdf", stamp + ".pdf"}
+		case saveFileTypeMHTML:
+			toFile = []string{"tmp", "mhtml", stamp + ".mhtml"}
 		}
 	}
 	return utils.OutputFile(filepath.Join(toFile...), bin)
@@ -0,0 +1,113 @@
+package rod
+
+import (
+	"context"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Worker implements these interfaces
+var _ proto.Client = &Worker{}
+var _ proto.Contextable = &Worker{}
+var _ proto.Sessionable = &Worker{}
+
+// Worker represents a service worker or a shared worker. The dedicated workers of a page are not supported.
+// It's a proto.Client, so you can call the cdp methods inside the worker, such as to test the offline logic of a PWA:
+//
+//     proto.NetworkEmulateNetworkConditions{Offline: true}.Call(worker)
+type Worker struct {
+	TargetID  proto.TargetTargetID
+	SessionID proto.TargetSessionID
+
+	ctx     context.Context
+	browser *Browser
+}
+
+// WorkerFromTarget attaches to the worker target.
+// If the target is not a service worker or a shared worker, it will return ErrExpectWorker.
+func (b *Browser) WorkerFromTarget(targetID proto.TargetTargetID) (*Worker, error) {
+	info, err := b.pageInfo(targetID)
+	if err != nil {
+		return nil, err
+	}
+	if !isWorker(info.Type) {
+		return nil, &ErrExpectWorker{info}
+	}
+
+	session, err := proto.TargetAttachToTarget{TargetID: targetID, Flatten: true}.Call(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Worker{
+		TargetID:  targetID,
+		SessionID: session.SessionID,
+		ctx:       b.ctx,
+		browser:   b,
+	}, nil
+}
+
+// WaitWorker waits for the next service worker or shared worker created in the browser.
+// Call it before the action that creates the worker, such as navigator.serviceWorker.register .
+func (b *Browser) WaitWorker() func() (*Worker, error) {
+	var targetID proto.TargetTargetID
+
+	wait := b.EachEvent(func(e *proto.TargetTargetCreated) bool {
+		if isWorker(e.TargetInfo.Type) {
+			targetID = e.TargetInfo.TargetID
+			return true
+		}
+		return false
+	})
+
+	return func() (*Worker, error) {
+		wait()
+		if targetID == "" {
+			return nil, b.ctx.Err()
+		}
+		return b.WorkerFromTarget(targetID)
+	}
+}
+
+func isWorker(t proto.TargetTargetInfoType) bool {
+	return t == proto.TargetTargetInfoTypeServiceWorker || t == proto.TargetTargetInfoTypeSharedWorker
+}
+
+// Context returns a clone with the specified ctx for chained sub-operations
+func (w *Worker) Context(ctx context.Context) *Worker {
+	newObj := *w
+	newObj.ctx = ctx
+	return &newObj
+}
+
+// GetContext of current instance
+func (w *Worker) GetContext() context.Context {
+	return w.ctx
+}
+
+// GetSessionID interface
+func (w *Worker) GetSessionID() proto.TargetSessionID {
+	return w.SessionID
+}
+
+// Call implements the proto.Client
+func (w *Worker) Call(ctx context.Context, sessionID, methodName string, params interface{}) (res []byte, err error) {
+	return w.browser.Call(ctx, sessionID, methodName, params)
+}
+
+// Eval the js expression in the global scope of the worker, such as `self.registration.scope`.
+// If the result is a promise, it will be awaited. The result is returned by value.
+func (w *Worker) Eval(js string) (*proto.RuntimeRemoteObject, error) {
+	res, err := proto.RuntimeEvaluate{
+		Expression:    js,
+		ReturnByValue: true,
+		AwaitPromise:  true,
+	}.Call(w)
+	if err != nil {
+		return nil, err
+	}
+	if res.ExceptionDetails != nil {
+		return nil, &ErrEval{res.ExceptionDetails}
+	}
+	return res.Result, nil
+}
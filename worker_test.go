@@ -0,0 +1,50 @@
+package rod_test
+
+import (
+	"context"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func (t T) Worker() {
+	s := t.Serve()
+	s.Route("/", ".html", "<html></html>")
+	s.Route("/sw.js", ".js", `self.ok = 'ok'`)
+	p := t.newPage(s.URL())
+	defer p.MustEval(`() => navigator.serviceWorker.getRegistrations().then(l => Promise.all(l.map(r => r.unregister())))`)
+
+	wait := t.browser.MustWaitWorker()
+	p.MustEval(`u => navigator.serviceWorker.register(u)`, s.URL("/sw.js"))
+	w := wait()
+
+	t.Eq(w.GetSessionID(), w.SessionID)
+	t.Eq(w.MustEval(`self.ok`).Str(), "ok")
+	t.Eq(w.MustEval(`Promise.resolve(1)`).Int(), 1)
+
+	_, err := w.Eval(`notExists()`)
+	t.Is(err, &rod.ErrEval{})
+
+	t.mc.stubErr(1, proto.RuntimeEvaluate{})
+	t.Err(w.Eval(`1`))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	t.Err(w.Context(ctx).Eval(`1`))
+	t.Eq(w.Context(ctx).GetContext(), ctx)
+
+	_, err = t.browser.WorkerFromTarget(p.TargetID)
+	t.Is(err, &rod.ErrExpectWorker{})
+	t.Has(err.Error(), "expect target to be a worker, but got:")
+
+	t.mc.stubErr(1, proto.TargetGetTargetInfo{})
+	t.Err(t.browser.WorkerFromTarget(w.TargetID))
+
+	t.mc.stubErr(1, proto.TargetAttachToTarget{})
+	t.Err(t.browser.WorkerFromTarget(w.TargetID))
+
+	b, cancel := t.browser.WithCancel()
+	waitErr := b.WaitWorker()
+	cancel()
+	t.Err(waitErr())
+}